@@ -0,0 +1,258 @@
+// Command admin runs database maintenance without starting the server:
+//
+//	admin migrate up|down|status [-steps N | -all] [-yes]
+//	admin seed [-count N] [-project ID]
+//	admin purge [-older-than 720h] [-dry-run]
+//	admin reindex
+//	admin check
+//
+// Every command accepts -config FILE, read like the server's.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"task-management/internal/config"
+	"task-management/internal/db/postgres"
+
+	"gorm.io/gorm"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	commands := map[string]func(args []string) int{
+		"migrate": runMigrate,
+		"seed":    runSeed,
+		"purge":   runPurge,
+		"reindex": runReindex,
+		"check":   runCheck,
+	}
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	os.Exit(run(os.Args[2:]))
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Usage: admin <command> [flags]
+
+Commands:
+  migrate up|down  apply the pending migrations, or revert the latest ones
+  migrate status   list the migrations and when they were applied
+  seed             insert demo tasks
+  purge            permanently remove tasks marked deleted
+  reindex          rebuild the full-text search index
+  check            verify database health
+
+Every command accepts -config FILE, defaulting to CONFIG_FILE.`)
+}
+
+// configPath is the configuration file given with -config
+var configPath string
+
+// newFlagSet returns the flag set of a command, accepting -config
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.StringVar(&configPath, "config", "", "YAML configuration file (defaults to CONFIG_FILE)")
+	return fs
+}
+
+// open loads the configuration and connects to its database without
+// migrating it
+func open() (*gorm.DB, *config.Config, bool) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, false
+	}
+
+	db, err := postgres.Open(cfg.Database, cfg.Log)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, false
+	}
+	return db, cfg, true
+}
+
+// runMigrate implements the migrate subcommand:
+//
+//	admin migrate up
+//	admin migrate down [-steps N | -all] -yes
+//	admin migrate status
+func runMigrate(args []string) int {
+	fs := newFlagSet("migrate")
+	steps := fs.Int("steps", 1, "number of migrations to revert on down")
+	all := fs.Bool("all", false, "revert every migration on down")
+	yes := fs.Bool("yes", false, "confirm reverting migrations, which may drop data")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: admin migrate up|down|status [flags]")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return 2
+	}
+	direction := args[0]
+	fs.Parse(args[1:])
+
+	db, _, ok := open()
+	if !ok {
+		return 1
+	}
+
+	switch direction {
+	case "up":
+		applied, err := postgres.Migrate(db)
+		for _, m := range applied {
+			fmt.Printf("Applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+	case "down":
+		if !*yes {
+			fmt.Fprintln(os.Stderr, "migrate down may drop tables and their data, pass -yes to confirm")
+			return 2
+		}
+		if *all {
+			migrations, err := postgres.Migrations()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 1
+			}
+			*steps = len(migrations)
+		}
+		reverted, err := postgres.Rollback(db, *steps)
+		for _, m := range reverted {
+			fmt.Printf("Reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(reverted) == 0 {
+			fmt.Println("No migrations to revert")
+		}
+	case "status":
+		states, err := postgres.MigrationStatus(db)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		for _, state := range states {
+			applied := "pending"
+			if state.AppliedAt != nil {
+				applied = state.AppliedAt.Local().Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-30s %s\n", state.Version, state.Name, applied)
+		}
+	default:
+		fs.Usage()
+		return 2
+	}
+	return 0
+}
+
+// runPurge implements the purge subcommand:
+//
+//	admin purge [-older-than 720h] [-dry-run]
+func runPurge(args []string) int {
+	fs := newFlagSet("purge")
+	olderThan := fs.Duration("older-than", 30*24*time.Hour, "only tasks deleted at least this long ago")
+	dryRun := fs.Bool("dry-run", false, "count the tasks without removing them")
+	fs.Parse(args)
+
+	db, _, ok := open()
+	if !ok {
+		return 1
+	}
+
+	count, err := postgres.PurgeDeletedTasks(context.Background(), db, time.Now().Add(-*olderThan), *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *dryRun {
+		fmt.Printf("%d tasks would be purged\n", count)
+	} else {
+		fmt.Printf("%d tasks purged\n", count)
+	}
+	return 0
+}
+
+// runReindex implements the reindex subcommand
+func runReindex(args []string) int {
+	fs := newFlagSet("reindex")
+	fs.Parse(args)
+
+	db, _, ok := open()
+	if !ok {
+		return 1
+	}
+
+	if err := postgres.ReindexSearch(context.Background(), db); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println("Search index rebuilt")
+	return 0
+}
+
+// runCheck implements the check subcommand. It exits with 1 when a check
+// fails, so it can back scripts and probes.
+//
+//	admin check [-json]
+func runCheck(args []string) int {
+	fs := newFlagSet("check")
+	asJSON := fs.Bool("json", false, "print the results as JSON")
+	fs.Parse(args)
+
+	db, _, ok := open()
+	if !ok {
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	results := postgres.Check(ctx, db)
+
+	healthy := true
+	for _, result := range results {
+		healthy = healthy && result.OK
+	}
+
+	if *asJSON {
+		out := json.NewEncoder(os.Stdout)
+		out.SetIndent("", "  ")
+		out.Encode(map[string]interface{}{"healthy": healthy, "checks": results})
+	} else {
+		for _, result := range results {
+			mark := "ok  "
+			if !result.OK {
+				mark = "FAIL"
+			}
+			fmt.Printf("%s %-10s %s\n", mark, result.Name, result.Detail)
+		}
+	}
+
+	if !healthy {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"task-management/internal/entities"
+	"task-management/internal/models"
+	"task-management/internal/services"
+
+	"github.com/go-playground/validator"
+	"github.com/gofrs/uuid"
+)
+
+// demoTasks are the templates seeded tasks are made from
+var demoTasks = []struct {
+	title       string
+	description string
+	labels      []string
+}{
+	{"Set up CI pipeline", "Run the build, vet and tests on every pull request.", []string{"devops"}},
+	{"Fix login redirect loop", "Users bounce between /login and /home after their session expires.", []string{"bug", "backend"}},
+	{"Design onboarding screens", "Three screens introducing boards, labels and due dates.", []string{"design", "frontend"}},
+	{"Write API documentation", "Document the task endpoints with request and response examples.", []string{"docs"}},
+	{"Add dark mode", "Follow the system preference and remember the user's choice.", []string{"frontend"}},
+	{"Migrate reports to the new schema", "Backfill the report tables and switch the readers over.", []string{"backend", "data"}},
+	{"Review accessibility audit", "Go through the findings and file tickets for each one.", []string{"frontend", "a11y"}},
+	{"Rotate database credentials", "Rotate the production credentials and update the secrets store.", []string{"devops", "security"}},
+}
+
+var demoStatuses = []entities.TaskStatus{
+	entities.StatusPending,
+	entities.StatusInProgress,
+	entities.StatusCompleted,
+	entities.StatusPending,
+	entities.StatusCancelled,
+}
+
+// runSeed implements the seed subcommand. Tasks go through the service layer
+// so they are validated and publish their events like any other task.
+//
+//	admin seed [-count N] [-project ID]
+func runSeed(args []string) int {
+	fs := newFlagSet("seed")
+	count := fs.Int("count", 20, "number of tasks to create")
+	project := fs.String("project", "", "project ID of the tasks")
+	fs.Parse(args)
+
+	var projectID *uuid.UUID
+	if *project != "" {
+		id, err := uuid.FromString(*project)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid project ID:", err)
+			return 2
+		}
+		projectID = &id
+	}
+
+	db, cfg, ok := open()
+	if !ok {
+		return 1
+	}
+	service := services.New(models.New(db), cfg.Service)
+	validate := validator.New()
+
+	now := time.Now().UTC().Truncate(24 * time.Hour)
+	for i := 0; i < *count; i++ {
+		demo := demoTasks[i%len(demoTasks)]
+		due := now.AddDate(0, 0, i%21-7)
+
+		req := &entities.TaskRequest{
+			Title:       demo.title,
+			Description: demo.description,
+			Status:      demoStatuses[i%len(demoStatuses)],
+			DueDate:     &due,
+			ProjectID:   projectID,
+			Labels:      demo.labels,
+		}
+		if i >= len(demoTasks) {
+			req.Title = fmt.Sprintf("%s (%d)", demo.title, i/len(demoTasks)+1)
+		}
+
+		if err := validate.Struct(req); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if _, err := service.CreateTask(context.Background(), req); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	fmt.Printf("%d demo tasks created\n", *count)
+	return 0
+}
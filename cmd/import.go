@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"task-management/internal/auth"
+	"task-management/internal/config"
+	"task-management/internal/db/postgres"
+	"task-management/internal/importer"
+	"task-management/internal/models"
+	"task-management/internal/services"
+
+	"github.com/go-playground/validator"
+	"github.com/gofrs/uuid"
+)
+
+// runImport implements the import subcommand:
+//
+//	server import [-config file] [-user-id id -project-id id] [-format csv|json] [-mapping title=Name,...] [-dry-run] <file>
+//
+// Rows are imported on behalf of the caller given with -user-id and
+// -project-id, like requests forwarded by the gateway. Without them, only
+// tasks without a project can be imported.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	configPath := fs.String("config", "", "YAML configuration file (defaults to CONFIG_FILE)")
+	userID := fs.String("user-id", "", "ID of the user importing the tasks")
+	projectID := fs.String("project-id", "", "project the tasks may belong to, requires -user-id")
+	format := fs.String("format", "", "file format, csv or json (defaults to the file extension)")
+	mapping := fs.String("mapping", "", "CSV column mapping as field=header pairs, e.g. title=Name,due_date=Due")
+	dryRun := fs.Bool("dry-run", false, "validate the file without importing it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: server import [flags] <file>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+
+	ctx, err := importContext(*userID, *projectID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer file.Close()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	db := postgres.Connect(cfg.Database, cfg.Log)
+	service := services.New(models.New(db), cfg.Service)
+
+	result, err := importer.Run(ctx, service, validator.New(), file, importer.Options{
+		Format:  *format,
+		Mapping: *mapping,
+		DryRun:  *dryRun,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+	out.Encode(result)
+
+	if len(result.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// importContext returns the context of an import made on behalf of the
+// given user and project, anonymous when both are empty
+func importContext(userID, projectID string) (context.Context, error) {
+	ctx := context.Background()
+	if userID == "" {
+		if projectID != "" {
+			return nil, errors.New("-project-id requires -user-id")
+		}
+		return ctx, nil
+	}
+
+	id, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -user-id %q", userID)
+	}
+	caller := auth.Caller{UserID: id}
+	if projectID != "" {
+		project, err := uuid.FromString(projectID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -project-id %q", projectID)
+		}
+		caller.ProjectID = &project
+	}
+
+	return auth.WithCaller(ctx, caller), nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"task-management/internal/config"
+	"task-management/internal/db/postgres"
+	"task-management/internal/logging"
+	"task-management/internal/models"
+	"task-management/internal/outbox"
+	"task-management/internal/webhook"
+)
+
+// runRelay implements the relay subcommand, which publishes outbox events
+// without serving the API:
+//
+//	server relay
+func runRelay(args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: server relay")
+		return 2
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	slog.SetDefault(logging.New(cfg.Log, os.Stdout))
+
+	model := models.New(postgres.Connect(cfg.Database, cfg.Log))
+
+	publishers, err := outboxPublishers(model, cfg.Outbox)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	slog.Info("outbox relay started", "publishers", len(publishers))
+	outbox.NewRelay(model.Outbox, publishers...).Run(ctx)
+	return 0
+}
+
+// outboxPublishers returns the consumers of outbox events. Webhooks are
+// always fed; the other publishers are enabled by the configuration.
+func outboxPublishers(model *models.Model, cfg config.Outbox) ([]outbox.Publisher, error) {
+	publishers := []outbox.Publisher{webhook.NewPublisher(model.Webhook)}
+
+	if cfg.Log {
+		publishers = append(publishers, outbox.LogPublisher{})
+	}
+
+	if cfg.HTTPURL != "" {
+		publishers = append(publishers, outbox.NewHTTPPublisher(cfg.HTTPURL))
+	}
+
+	if cfg.NATSURL != "" {
+		nats, err := outbox.NewNATSPublisher(cfg.NATSURL, cfg.NATSSubject)
+		if err != nil {
+			return nil, err
+		}
+		publishers = append(publishers, nats)
+	}
+
+	return publishers, nil
+}
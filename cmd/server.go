@@ -1,47 +1,183 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	_ "task-management/docs"
+	"task-management/internal/auth"
+	"task-management/internal/collab"
+	"task-management/internal/config"
 	"task-management/internal/db/postgres"
 	"task-management/internal/handlers"
+	"task-management/internal/health"
+	"task-management/internal/logging"
+	"task-management/internal/metrics"
 	"task-management/internal/models"
+	"task-management/internal/outbox"
+	"task-management/internal/ratelimit"
 	"task-management/internal/services"
+	"task-management/internal/stream"
+	"task-management/internal/tracing"
 	"task-management/internal/web/rest"
+	"task-management/internal/web/rpc"
+	"task-management/internal/webhook"
 
 	"github.com/go-playground/validator"
-	"github.com/joho/godotenv"
 	"github.com/rs/cors"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
+// version is the build version reported by /status, set with
+// -ldflags "-X main.version=<version>". It defaults to the VCS revision.
+var version string
+
 func main() {
 
-	if os.Getenv("ENV") == "" {
-		if err := godotenv.Load(); err != nil {
-			log.Fatalln("Error loading env file", err)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			os.Exit(runImport(os.Args[2:]))
+		case "relay":
+			os.Exit(runRelay(os.Args[2:]))
+		}
+	}
+
+	configPath := flag.String("config", "", "YAML configuration file (defaults to CONFIG_FILE)")
+	migrate := flag.Bool("migrate", true, "apply pending database migrations on start, overriding the configuration")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fatal(slog.Default(), "failed to load configuration", err)
+	}
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "migrate" {
+			cfg.Database.Migrate = *migrate
 		}
+	})
+
+	logger := logging.New(cfg.Log, os.Stdout)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, version)
+	if err != nil {
+		fatal(logger, "failed to set up tracing", err)
 	}
 
 	v := validator.New()
 
-	db := postgres.Connect()
+	db := postgres.Connect(cfg.Database, cfg.Log)
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		fatal(logger, "failed to register query metrics", err)
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		fatal(logger, "failed to register query tracing", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		if err := metrics.RegisterDB(sqlDB, "tasks"); err != nil {
+			fatal(logger, "failed to register pool metrics", err)
+		}
+	}
 
 	model := models.New(db)
-	fmt.Println("Model layer initialized")
+	if err := metrics.RegisterTasks(model.Task); err != nil {
+		fatal(logger, "failed to register task metrics", err)
+	}
+
+	service := tracing.Service(services.New(model, cfg.Service))
 
-	service := services.New(model)
-	fmt.Println("Service layer initialized")
+	checker := health.New(version, cfg.Health.Timeout, cfg.Health.StatusToken)
+	checker.AddCheck("database", func(ctx context.Context) error {
+		return postgres.Ping(ctx, db)
+	})
+	checker.AddCheck("migrations", func(ctx context.Context) error {
+		return postgres.CheckMigrated(ctx, db)
+	})
+
+	// Background workers run until workersCtx is cancelled on shutdown
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	startWorker := func(name string, run func(ctx context.Context)) {
+		stopped := checker.Worker(name)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			defer stopped()
+			run(workersCtx)
+		}()
+	}
+
+	publishers, err := outboxPublishers(model, cfg.Outbox)
+	if err != nil {
+		fatal(logger, "failed to configure outbox publishers", err)
+	}
+	startWorker("outbox relay", outbox.NewRelay(model.Outbox, publishers...).Run)
+	logger.Info("outbox relay started", "publishers", len(publishers))
+
+	startWorker("webhook worker", webhook.NewWorker(model.Webhook).Run)
+	logger.Info("webhook delivery worker started")
+
+	events := stream.NewBroker(stream.DefaultLogSize)
+	startWorker("event listener", func(ctx context.Context) {
+		stream.Listen(ctx, cfg.Database.URL, model.Outbox, events)
+	})
+	logger.Info("event stream listener started")
+
+	tickets, err := collabTickets(logger, cfg.Collab)
+	if err != nil {
+		fatal(logger, "failed to generate a collaboration ticket secret", err)
+	}
+	hub := collab.NewHub(service, cfg.CORS.AllowedOrigins, tickets)
+	startWorker("collaboration hub", func(ctx context.Context) {
+		hub.Run(ctx, events)
+	})
+	logger.Info("collaboration hub started")
 
-	handler := handlers.New(service, v)
-	fmt.Println("Handler layer initialized")
+	// Serving errors end the process like signals do
+	serveErrs := make(chan error, 2)
 
-	r := rest.NewRouter(handler)
-	fmt.Println("Routers loaded")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+	if err != nil {
+		fatal(logger, "failed to listen for gRPC", err)
+	}
+	grpcServer := rpc.NewServer(service, v, events, logger)
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			serveErrs <- fmt.Errorf("gRPC server: %w", err)
+		}
+	}()
+	logger.Info("gRPC server listening", "port", cfg.GRPC.Port)
+
+	handler, err := handlers.New(service, v, events, hub, cfg.HTTP)
+	if err != nil {
+		fatal(logger, "failed to build the GraphQL schema", err)
+	}
+
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		var store ratelimit.Store = ratelimit.NewMemoryStore()
+		if cfg.RateLimit.Store == "postgres" {
+			store = ratelimit.NewPostgresStore(db)
+		}
+		limiter = ratelimit.New(cfg.RateLimit, store)
+		logger.Info("rate limiting enabled", "store", cfg.RateLimit.Store)
+	}
+
+	r := rest.NewRouter(handler, checker, logger, limiter)
+
+	logger.Info("GraphQL endpoint available", "path", "/graphql")
 
 	r.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"),
@@ -49,23 +185,127 @@ func main() {
 		httpSwagger.DocExpansion("none"),
 		httpSwagger.DomID("swagger-ui"),
 	))
-	fmt.Println("Swagger documentation available at /swagger/index.html")
-
-	allowedOrigins := []string{"http://localhost:3000"}
-	if os.Getenv("ENV") == "prod" {
-		allowedOrigins = []string{"https://task-management-frontend.vercel.app"}
-	}
+	logger.Info("Swagger documentation available", "path", "/swagger/index.html")
 
 	c := cors.New(cors.Options{
-		AllowedOrigins:   allowedOrigins,
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
 		AllowCredentials: true,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-User-ID", "X-Project-ID", "Last-Event-ID", "X-Request-ID"},
+		ExposedHeaders:   []string{"X-Request-ID", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"},
 	})
 
 	corsHandler := c.Handler(r)
 
-	fmt.Println("Server listening on port 8080...")
-	http.ListenAndServe(":8080", corsHandler)
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.HTTP.Port),
+		Handler:           corsHandler,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErrs <- fmt.Errorf("HTTP server: %w", err)
+		}
+	}()
+	logger.Info("HTTP server listening", "port", cfg.HTTP.Port)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	exitCode := 0
+	select {
+	case sig := <-signals:
+		logger.Info("shutting down", "signal", sig.String())
+	case err := <-serveErrs:
+		logger.Error("shutting down after error", "error", err)
+		exitCode = 1
+	}
+	// A second signal kills the process without waiting for the drain
+	signal.Reset(os.Interrupt, syscall.SIGTERM)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Workers go first, so the hub does not subscribe again once the broker
+	// closes. Closing the broker then ends the event streams, which would
+	// otherwise hold the drain until the deadline.
+	stopWorkers()
+	events.Close()
+	hub.Shutdown()
+
+	var drain sync.WaitGroup
+	drain.Add(2)
+	go func() {
+		defer drain.Done()
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Error("HTTP connections not drained", "error", err)
+			server.Close()
+			exitCode = 1
+		}
+	}()
+	go func() {
+		defer drain.Done()
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			logger.Error("gRPC calls not drained", "error", ctx.Err())
+			grpcServer.Stop()
+		}
+	}()
+	drain.Wait()
+
+	stopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logger.Error("background workers not stopped", "error", ctx.Err())
+		exitCode = 1
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("failed to flush spans", "error", err)
+	}
+
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			logger.Error("failed to close the database pool", "error", err)
+		}
+	}
+
+	logger.Info("server stopped")
+	os.Exit(exitCode)
+}
+
+// collabTickets signs collaboration tickets with the configured secret, or
+// with a random one when there is none, accepted by this instance only
+func collabTickets(logger *slog.Logger, cfg config.Collab) (*auth.Tickets, error) {
+	secret := []byte(cfg.TicketSecret)
+	if len(secret) == 0 {
+		logger.Warn("no collaboration ticket secret configured, tickets are only accepted by the instance issuing them")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+	return auth.NewTickets(secret, cfg.TicketTTL), nil
+}
 
+// fatal logs err and exits, for failures before the server starts
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"task-management/internal/entities"
+)
+
+// app is the state shared by the commands
+type app struct {
+	opts    globalOptions
+	cfg     *config
+	profile profile
+	client  *client
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+func newApp(opts globalOptions, stdout, stderr io.Writer) (*app, error) {
+	path, err := configPath(opts.config)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return &app{opts: opts, cfg: cfg, stdout: stdout, stderr: stderr}, nil
+}
+
+// flagSet returns the flag set of a command, accepting the global flags too
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	a.opts.register(fs)
+	return fs
+}
+
+// parse parses the flags of a command, which may come before, between or
+// after its arguments, and resolves the profile to use. It returns the
+// arguments.
+func (a *app) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	p, err := a.cfg.resolve(a.opts)
+	if err != nil {
+		return nil, err
+	}
+	a.profile = p
+	a.client = newClient(p)
+
+	return positional, nil
+}
+
+// stringList is a flag that can be repeated or given comma separated values.
+// An empty value clears the list.
+type stringList struct {
+	values []string
+	set    bool
+}
+
+func (l *stringList) String() string {
+	return strings.Join(l.values, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	if !l.set {
+		l.values = []string{}
+		l.set = true
+	}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			l.values = append(l.values, v)
+		}
+	}
+	return nil
+}
+
+// parseStatus checks a status given on the command line
+func parseStatus(raw string) (entities.TaskStatus, error) {
+	status := entities.TaskStatus(raw)
+	if !status.Valid() {
+		return "", fmt.Errorf("%w: invalid status %q, expected Pending, InProgress, Completed or Cancelled", errUsage, raw)
+	}
+	return status, nil
+}
+
+// parseDue reads a due date as YYYY-MM-DD, which is due at midnight UTC, or
+// as an RFC 3339 time. An empty value clears the due date.
+func parseDue(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid due date %q, expected YYYY-MM-DD or RFC 3339", errUsage, raw)
+	}
+	return &t, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// client calls the REST API
+type client struct {
+	baseURL string
+	profile profile
+	http    *http.Client
+}
+
+func newClient(p profile) *client {
+	return &client{
+		baseURL: strings.TrimRight(p.URL, "/"),
+		profile: p,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// apiError is an error response of the API
+type apiError struct {
+	Status  int
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Message, e.Status)
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response
+// into out when given
+func (c *client) do(method, path string, query url.Values, body, out interface{}) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// The gateway authenticates the token and forwards the caller's identity.
+	// The service itself never reads it: without a gateway in front, it
+	// trusts the identity headers below directly.
+	if c.profile.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.profile.Token)
+	}
+	if c.profile.UserID != "" {
+		req.Header.Set("X-User-ID", c.profile.UserID)
+	}
+	if c.profile.Project != "" {
+		req.Header.Set("X-Project-ID", c.profile.Project)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &apiError{Status: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
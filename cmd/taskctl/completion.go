@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const bashCompletion = `# bash completion for taskctl, load with: source <(taskctl completion bash)
+_taskctl() {
+	local cur prev
+	cur="${COMP_WORDS[COMP_CWORD]}"
+	prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+	case "$prev" in
+	--status)
+		COMPREPLY=($(compgen -W "%[3]s" -- "$cur"))
+		return
+		;;
+	-o|--output|--default-output)
+		COMPREPLY=($(compgen -W "table json yaml" -- "$cur"))
+		return
+		;;
+	--profile)
+		COMPREPLY=($(compgen -W "$(taskctl config view -o json 2>/dev/null | sed -n 's/^    "\([^"]*\)": {$/\1/p')" -- "$cur"))
+		return
+		;;
+	esac
+
+	if [[ $COMP_CWORD -eq 1 ]]; then
+		COMPREPLY=($(compgen -W "%[1]s help" -- "$cur"))
+		return
+	fi
+
+	case "${COMP_WORDS[1]}" in
+	config)
+		[[ $COMP_CWORD -eq 2 ]] && COMPREPLY=($(compgen -W "set use view delete" -- "$cur"))
+		;;
+	completion)
+		COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur"))
+		;;
+	*)
+		[[ "$cur" == -* ]] && COMPREPLY=($(compgen -W "%[2]s" -- "$cur"))
+		;;
+	esac
+}
+complete -F _taskctl taskctl
+`
+
+const zshCompletion = `#compdef taskctl
+# zsh completion for taskctl, load with: source <(taskctl completion zsh)
+_taskctl() {
+	local -a commands
+	commands=(%[1]s)
+
+	case "${words[CURRENT-1]}" in
+	--status) compadd %[3]s; return ;;
+	-o|--output|--default-output) compadd table json yaml; return ;;
+	esac
+
+	if (( CURRENT == 2 )); then
+		_describe 'command' commands
+		return
+	fi
+
+	case "${words[2]}" in
+	config) (( CURRENT == 3 )) && compadd set use view delete ;;
+	completion) compadd bash zsh fish ;;
+	*) [[ "${words[CURRENT]}" == -* ]] && compadd -- %[2]s ;;
+	esac
+}
+compdef _taskctl taskctl
+`
+
+const fishCompletion = `# fish completion for taskctl, load with: taskctl completion fish | source
+complete -c taskctl -f
+%[1]scomplete -c taskctl -l status -xa '%[3]s'
+complete -c taskctl -s o -l output -xa 'table json yaml'
+complete -c taskctl -l profile -x
+complete -c taskctl -l url -x
+complete -c taskctl -l token -x
+complete -c taskctl -n '__fish_seen_subcommand_from config' -xa 'set use view delete'
+complete -c taskctl -n '__fish_seen_subcommand_from completion' -xa 'bash zsh fish'
+`
+
+// completionFlags are the flags offered by the shell completions
+var completionFlags = []string{
+	"--profile", "--url", "--token", "--output", "--config",
+	"--status", "--page", "--page-size", "--sort", "--filter", "--view",
+	"--title", "--description", "--due", "--label", "--project",
+}
+
+const completionStatuses = "Pending InProgress Completed Cancelled"
+
+func runCompletion(a *app, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected bash, zsh or fish", errUsage)
+	}
+
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[i] = cmd.name
+	}
+	flags := strings.Join(completionFlags, " ")
+
+	switch args[0] {
+	case "bash":
+		fmt.Fprintf(a.stdout, bashCompletion, strings.Join(names, " "), flags, completionStatuses)
+	case "zsh":
+		described := make([]string, len(commands))
+		for i, cmd := range commands {
+			described[i] = fmt.Sprintf("'%s:%s'", cmd.name, cmd.summary)
+		}
+		fmt.Fprintf(a.stdout, zshCompletion, strings.Join(described, " "), flags, completionStatuses)
+	case "fish":
+		var subcommands strings.Builder
+		for _, cmd := range commands {
+			fmt.Fprintf(&subcommands, "complete -c taskctl -n '__fish_use_subcommand' -a %s -d '%s'\n", cmd.name, cmd.summary)
+		}
+		fmt.Fprintf(a.stdout, fishCompletion, subcommands.String(), flags, completionStatuses)
+	default:
+		return fmt.Errorf("%w: unknown shell %q", errUsage, args[0])
+	}
+	return nil
+}
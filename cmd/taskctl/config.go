@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultURL = "http://localhost:8080"
+
+// profile holds the settings used to reach one deployment of the API
+type profile struct {
+	URL     string `yaml:"url"`
+	Token   string `yaml:"token,omitempty"`
+	Output  string `yaml:"output,omitempty"`
+	UserID  string `yaml:"user_id,omitempty"`
+	Project string `yaml:"project_id,omitempty"`
+}
+
+// config is the taskctl configuration file
+type config struct {
+	Current  string              `yaml:"current_profile"`
+	Profiles map[string]*profile `yaml:"profiles"`
+
+	path string
+}
+
+// configPath returns the configuration file to use: --config, then
+// TASKCTL_CONFIG, then taskctl/config.yaml in the user configuration directory
+func configPath(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if env := os.Getenv("TASKCTL_CONFIG"); env != "" {
+		return env, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "taskctl", "config.yaml"), nil
+}
+
+// loadConfig reads the configuration file. A missing file is an empty
+// configuration.
+func loadConfig(path string) (*config, error) {
+	cfg := &config{Profiles: map[string]*profile{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*profile{}
+	}
+
+	return cfg, nil
+}
+
+// save writes the configuration file, readable only by the user since it
+// holds tokens
+func (c *config) save() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o600)
+}
+
+// names returns the profile names in order
+func (c *config) names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolve merges the selected profile, the environment and the flags, in
+// increasing order of precedence
+func (c *config) resolve(opts globalOptions) (profile, error) {
+	name := firstOf(opts.profile, os.Getenv("TASKCTL_PROFILE"), c.Current)
+
+	p := profile{URL: defaultURL}
+	if name != "" {
+		selected, ok := c.Profiles[name]
+		if !ok {
+			return p, fmt.Errorf("unknown profile %q", name)
+		}
+		p = *selected
+	}
+
+	p.URL = firstOf(opts.url, os.Getenv("TASKCTL_URL"), p.URL, defaultURL)
+	p.Token = firstOf(opts.token, os.Getenv("TASKCTL_TOKEN"), p.Token)
+	p.Output = firstOf(opts.output, p.Output, "table")
+
+	return p, nil
+}
+
+// firstOf returns the first non-empty value
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+// Command taskctl is a command-line client for the task API.
+//
+//	taskctl [global flags] <command> [flags] [args]
+//
+// Run taskctl help for the list of commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// command is a taskctl subcommand
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(app *app, args []string) error
+}
+
+// commands is set in init as completion refers to it
+var commands []command
+
+func init() {
+	commands = []command{
+		{"list", "list [--status S] [--page N] [--page-size N] [--sort KEYS] [--filter EXPR]", "List tasks", runList},
+		{"get", "get <id>", "Show a task", runGet},
+		{"create", "create --title T [--description D] [--status S] [--due DATE] [--label L]... [--project ID]", "Create a task", runCreate},
+		{"update", "update <id> [--title T] [--description D] [--status S] [--due DATE] [--label L]... [--project ID]", "Change the given fields of a task", runUpdate},
+		{"delete", "delete <id>...", "Delete tasks", runDelete},
+		{"done", "done <id>...", "Mark tasks as completed", runDone},
+		{"config", "config set|use|view|delete ...", "Manage profiles", runConfig},
+		{"completion", "completion bash|zsh|fish", "Print a shell completion script", runCompletion},
+	}
+}
+
+// errUsage reports invalid arguments, after which the usage is printed
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("taskctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	opts := globalOptions{}
+	opts.register(global)
+	global.Usage = func() { usage(stderr) }
+
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+	if global.NArg() == 0 || global.Arg(0) == "help" {
+		usage(stdout)
+		return 0
+	}
+
+	name, rest := global.Arg(0), global.Args()[1:]
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		app, err := newApp(opts, stdout, stderr)
+		if err == nil {
+			err = cmd.run(app, rest)
+		}
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			fmt.Fprintf(stderr, "%v\nUsage: taskctl %s\n", err, cmd.usage)
+			return 2
+		case err != nil:
+			fmt.Fprintln(stderr, "Error:", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "Unknown command %q\n\n", name)
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: taskctl [global flags] <command> [flags] [args]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-11s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\nGlobal flags, also accepted after the command:")
+	fmt.Fprintln(w, "  --profile NAME   profile to use (TASKCTL_PROFILE, the current profile by default)")
+	fmt.Fprintln(w, "  --url URL        API base URL (TASKCTL_URL)")
+	fmt.Fprintln(w, "  --token TOKEN    API token checked by the gateway (TASKCTL_TOKEN)")
+	fmt.Fprintln(w, "  -o, --output F   output format: table, json or yaml")
+	fmt.Fprintln(w, "  --config PATH    configuration file (TASKCTL_CONFIG)")
+}
+
+// globalOptions are the flags accepted before and after any command
+type globalOptions struct {
+	profile string
+	url     string
+	token   string
+	output  string
+	config  string
+}
+
+func (o *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.profile, "profile", o.profile, "profile to use")
+	fs.StringVar(&o.url, "url", o.url, "API base URL")
+	fs.StringVar(&o.token, "token", o.token, "API token checked by the gateway")
+	fs.StringVar(&o.output, "output", o.output, "output format: table, json or yaml")
+	fs.StringVar(&o.output, "o", o.output, "shorthand for --output")
+	fs.StringVar(&o.config, "config", o.config, "configuration file")
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"task-management/internal/entities"
+
+	"gopkg.in/yaml.v3"
+)
+
+// printTasks writes tasks in the requested format
+func printTasks(w io.Writer, format string, tasks []*entities.TaskResponse) error {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tTITLE\tSTATUS\tDUE\tLABELS")
+		for _, task := range tasks {
+			due := "-"
+			if task.DueDate != nil {
+				due = task.DueDate.Format("2006-01-02")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", task.ID, truncate(task.Title, 50), task.Status, due, strings.Join(task.Labels, ","))
+		}
+		return tw.Flush()
+	default:
+		return printValue(w, format, tasks)
+	}
+}
+
+// printTask writes a single task in the requested format
+func printTask(w io.Writer, format string, task *entities.TaskResponse) error {
+	if format != "table" {
+		return printValue(w, format, task)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", task.ID)
+	fmt.Fprintf(tw, "Title:\t%s\n", task.Title)
+	fmt.Fprintf(tw, "Status:\t%s\n", task.Status)
+	if task.Description != "" {
+		fmt.Fprintf(tw, "Description:\t%s\n", task.Description)
+	}
+	if task.DueDate != nil {
+		fmt.Fprintf(tw, "Due:\t%s\n", task.DueDate.Format("2006-01-02 15:04 MST"))
+	}
+	if task.ProjectID != nil {
+		fmt.Fprintf(tw, "Project:\t%s\n", task.ProjectID)
+	}
+	if len(task.Labels) > 0 {
+		fmt.Fprintf(tw, "Labels:\t%s\n", strings.Join(task.Labels, ", "))
+	}
+	fmt.Fprintf(tw, "Created:\t%s\n", task.CreatedAt.Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(tw, "Updated:\t%s\n", task.UpdatedAt.Format("2006-01-02 15:04 MST"))
+	return tw.Flush()
+}
+
+// printValue writes v as JSON or YAML. YAML goes through JSON so both use the
+// field names of the API.
+func printValue(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(generic); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("%w: unknown output format %q, expected table, json or yaml", errUsage, format)
+	}
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
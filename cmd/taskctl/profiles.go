@@ -0,0 +1,170 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// runConfig manages the profiles of the configuration file:
+//
+//	taskctl config set <name> [--url URL] [--token TOKEN] [--default-output F] [--user-id ID] [--project-id ID] [--use]
+//	taskctl config use <name>
+//	taskctl config view
+//	taskctl config delete <name>
+//
+// The token is for the gateway, which turns it into the caller's identity.
+// Profiles reaching the service directly set --user-id and --project-id
+// instead.
+func runConfig(a *app, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected set, use, view or delete", errUsage)
+	}
+
+	switch args[0] {
+	case "set":
+		return configSet(a, args[1:])
+	case "use":
+		name, err := profileName(a, "use", args[1:])
+		if err != nil {
+			return err
+		}
+		if _, ok := a.cfg.Profiles[name]; !ok {
+			return fmt.Errorf("unknown profile %q", name)
+		}
+		a.cfg.Current = name
+		return a.cfg.save()
+	case "delete":
+		name, err := profileName(a, "delete", args[1:])
+		if err != nil {
+			return err
+		}
+		if _, ok := a.cfg.Profiles[name]; !ok {
+			return fmt.Errorf("unknown profile %q", name)
+		}
+		delete(a.cfg.Profiles, name)
+		if a.cfg.Current == name {
+			a.cfg.Current = ""
+		}
+		return a.cfg.save()
+	case "view":
+		return configView(a, args[1:])
+	}
+
+	return fmt.Errorf("%w: unknown config command %q", errUsage, args[0])
+}
+
+func profileName(a *app, name string, args []string) (string, error) {
+	args, err := a.parse(a.flagSet(name), args)
+	if err != nil {
+		return "", err
+	}
+	if len(args) != 1 {
+		return "", fmt.Errorf("%w: expected a profile name", errUsage)
+	}
+	return args[0], nil
+}
+
+func configSet(a *app, args []string) error {
+	// The connection flags describe the profile here, they are not global
+	// overrides, so this command has its own flag set
+	fs := a.flagSet("config set")
+	use := fs.Bool("use", false, "make it the current profile")
+	output := fs.String("default-output", "", "default output format of the profile")
+	userID := fs.String("user-id", "", "X-User-ID sent when talking to the service without a gateway")
+	projectID := fs.String("project-id", "", "X-Project-ID sent when talking to the service without a gateway")
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("%w: expected a profile name", errUsage)
+	}
+	name := positional[0]
+
+	p, ok := a.cfg.Profiles[name]
+	if !ok {
+		p = &profile{URL: defaultURL}
+		a.cfg.Profiles[name] = p
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "url":
+			p.URL = a.opts.url
+		case "token":
+			p.Token = a.opts.token
+		case "default-output":
+			p.Output = *output
+		case "user-id":
+			p.UserID = *userID
+		case "project-id":
+			p.Project = *projectID
+		}
+	})
+	if p.Output != "" && p.Output != "table" && p.Output != "json" && p.Output != "yaml" {
+		return fmt.Errorf("%w: unknown output format %q, expected table, json or yaml", errUsage, p.Output)
+	}
+
+	if *use || a.cfg.Current == "" {
+		a.cfg.Current = name
+	}
+	if err := a.cfg.save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stderr, "Saved profile %q to %s\n", name, a.cfg.path)
+	return nil
+}
+
+// configView lists the profiles with their tokens masked
+func configView(a *app, args []string) error {
+	if _, err := a.parse(a.flagSet("config view"), args); err != nil {
+		return err
+	}
+
+	if a.profile.Output != "table" {
+		masked := map[string]profile{}
+		for name, p := range a.cfg.Profiles {
+			masked[name] = p.masked()
+		}
+		return printValue(a.stdout, a.profile.Output, map[string]interface{}{
+			"path":            a.cfg.path,
+			"current_profile": a.cfg.Current,
+			"profiles":        masked,
+		})
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CURRENT\tNAME\tURL\tTOKEN\tOUTPUT")
+	for _, name := range a.cfg.names() {
+		p := a.cfg.Profiles[name].masked()
+		current := ""
+		if name == a.cfg.Current {
+			current = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", current, name, p.URL, p.Token, p.Output)
+	}
+	return tw.Flush()
+}
+
+// masked returns the profile with all but the end of its token hidden
+func (p profile) masked() profile {
+	if n := len(p.Token); n > 0 {
+		keep := 0
+		if n > 8 {
+			keep = 4
+		}
+		p.Token = strings.Repeat("*", 8) + p.Token[n-keep:]
+	}
+	return p
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+)
+
+// fakeAPI serves a single task and records the requests
+type fakeAPI struct {
+	task     entities.TaskResponse
+	requests []*http.Request
+	bodies   []string
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body bytes.Buffer
+	body.ReadFrom(r.Body)
+	f.requests = append(f.requests, r)
+	f.bodies = append(f.bodies, body.String())
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/tasks":
+		json.NewEncoder(w).Encode([]entities.TaskResponse{f.task})
+	case r.Method == http.MethodGet && r.URL.Path == "/api/tasks/"+f.task.ID.String():
+		json.NewEncoder(w).Encode(f.task)
+	case r.Method == http.MethodPut:
+		json.Unmarshal(body.Bytes(), &f.task)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/tasks/bulk":
+		json.NewEncoder(w).Encode(entities.BulkResponse{Failed: 1, Results: []entities.BulkItemResult{
+			{Index: 0, Status: entities.BulkSucceeded},
+			{Index: 1, Status: entities.BulkFailed, Error: "task not found"},
+		}})
+	default:
+		http.Error(w, "task not found", http.StatusNotFound)
+	}
+}
+
+func setup(t *testing.T) (*fakeAPI, func(args ...string) (int, string, string)) {
+	api := &fakeAPI{task: entities.TaskResponse{
+		ID:     uuid.Must(uuid.NewV4()),
+		Title:  "Write docs",
+		Status: entities.StatusPending,
+		Labels: []string{"docs"},
+	}}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TASKCTL_PROFILE", "")
+	t.Setenv("TASKCTL_URL", "")
+	t.Setenv("TASKCTL_TOKEN", "")
+
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(append([]string{"--config", config}, args...), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	if code, _, stderr := run("config", "set", "local", "--url", srv.URL, "--token", "secret-token"); code != 0 {
+		t.Fatalf("config set exited %d: %s", code, stderr)
+	}
+
+	return api, run
+}
+
+func TestList(t *testing.T) {
+	api, run := setup(t)
+
+	code, stdout, stderr := run("list", "--status", "Pending", "--page-size", "5", "-o", "yaml")
+	if code != 0 {
+		t.Fatalf("list exited %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "title: Write docs") {
+		t.Errorf("list output = %q, want YAML with API field names", stdout)
+	}
+
+	r := api.requests[0]
+	if r.URL.Query().Get("status") != "Pending" || r.URL.Query().Get("pageSize") != "5" {
+		t.Errorf("list query = %s", r.URL.RawQuery)
+	}
+	if r.Header.Get("Authorization") != "Bearer secret-token" {
+		t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+	}
+
+	if code, _, _ := run("list", "--status", "Later"); code != 2 {
+		t.Errorf("list with an invalid status exited %d, want 2", code)
+	}
+}
+
+func TestUpdate_keepsOtherFields(t *testing.T) {
+	api, run := setup(t)
+
+	code, stdout, stderr := run("update", api.task.ID.String(), "--status", "InProgress", "-o", "json")
+	if code != 0 {
+		t.Fatalf("update exited %d: %s", code, stderr)
+	}
+
+	var got entities.TaskResponse
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("update output %q: %v", stdout, err)
+	}
+	if got.Status != entities.StatusInProgress || got.Title != "Write docs" || len(got.Labels) != 1 {
+		t.Errorf("updated task = %+v, want only the status changed", got)
+	}
+}
+
+func TestDone_reportsFailures(t *testing.T) {
+	api, run := setup(t)
+
+	code, stdout, _ := run("done", api.task.ID.String(), uuid.Must(uuid.NewV4()).String())
+	if code != 1 {
+		t.Errorf("done exited %d, want 1 for the failed task", code)
+	}
+	if !strings.Contains(stdout, "task not found") {
+		t.Errorf("done output = %q", stdout)
+	}
+	if !strings.Contains(api.bodies[0], `"op":"set_status"`) {
+		t.Errorf("done request = %s", api.bodies[0])
+	}
+}
+
+func TestConfig(t *testing.T) {
+	_, run := setup(t)
+
+	if code, _, stderr := run("config", "set", "prod", "--url", "https://tasks.example.com", "--default-output", "json", "--use"); code != 0 {
+		t.Fatalf("config set exited %d: %s", code, stderr)
+	}
+
+	code, stdout, _ := run("config", "view", "-o", "table")
+	if code != 0 {
+		t.Fatalf("config view exited %d", code)
+	}
+	if strings.Contains(stdout, "secret-token") || !strings.Contains(stdout, "********oken") {
+		t.Errorf("config view does not mask tokens: %q", stdout)
+	}
+	if !strings.Contains(stdout, "\n*") || !strings.HasPrefix(strings.Fields(stdout[strings.Index(stdout, "\n*"):])[1], "prod") {
+		t.Errorf("config view = %q, want prod current", stdout)
+	}
+
+	if code, _, _ := run("config", "use", "staging"); code != 1 {
+		t.Errorf("config use of an unknown profile exited %d, want 1", code)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	_, run := setup(t)
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		code, stdout, _ := run("completion", shell)
+		if code != 0 || !strings.Contains(stdout, "done") || strings.Contains(stdout, "%!") {
+			t.Errorf("completion %s exited %d:\n%s", shell, code, stdout)
+		}
+	}
+}
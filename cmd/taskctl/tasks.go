@@ -0,0 +1,290 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"text/tabwriter"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+)
+
+func runList(a *app, args []string) error {
+	fs := a.flagSet("list")
+	status := fs.String("status", "", "only tasks with this status")
+	page := fs.Int("page", 1, "page number")
+	pageSize := fs.Int("page-size", 10, "tasks per page")
+	sort := fs.String("sort", "", "comma separated sort keys, prefix with - for descending")
+	filter := fs.String("filter", "", "filter expression, e.g. 'label:backend AND due<2026-11-01'")
+	view := fs.String("view", "", "saved view ID providing defaults")
+
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", errUsage, args)
+	}
+
+	query := url.Values{"include": {"labels"}}
+	query.Set("page", strconv.Itoa(*page))
+	query.Set("pageSize", strconv.Itoa(*pageSize))
+	if *status != "" {
+		if _, err := parseStatus(*status); err != nil {
+			return err
+		}
+		query.Set("status", *status)
+	}
+	for key, value := range map[string]string{"sort": *sort, "filter": *filter, "view": *view} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	var tasks []*entities.TaskResponse
+	if err := a.client.do(http.MethodGet, "/api/tasks", query, nil, &tasks); err != nil {
+		return err
+	}
+
+	return printTasks(a.stdout, a.profile.Output, tasks)
+}
+
+func runGet(a *app, args []string) error {
+	args, err := a.parse(a.flagSet("get"), args)
+	if err != nil {
+		return err
+	}
+	id, err := singleID(args)
+	if err != nil {
+		return err
+	}
+
+	task, err := a.getTask(id)
+	if err != nil {
+		return err
+	}
+
+	return printTask(a.stdout, a.profile.Output, task)
+}
+
+// taskFlags are the task fields settable from the command line
+type taskFlags struct {
+	title       *string
+	description *string
+	status      *string
+	due         *string
+	project     *string
+	labels      *stringList
+}
+
+func registerTaskFlags(fs *flag.FlagSet, defaultStatus string) taskFlags {
+	f := taskFlags{
+		title:       fs.String("title", "", "task title"),
+		description: fs.String("description", "", "task description"),
+		status:      fs.String("status", defaultStatus, "Pending, InProgress, Completed or Cancelled"),
+		due:         fs.String("due", "", "due date, YYYY-MM-DD or RFC 3339; empty to clear"),
+		project:     fs.String("project", "", "project ID"),
+		labels:      &stringList{},
+	}
+	fs.Var(f.labels, "label", "label, repeatable or comma separated; empty to clear")
+	return f
+}
+
+// apply sets the fields given on the command line on req. Only the flags
+// actually passed are applied, so updates keep the other fields.
+func (f taskFlags) apply(fs *flag.FlagSet, req *entities.TaskRequest) error {
+	var err error
+	fs.Visit(func(fl *flag.Flag) {
+		if err != nil {
+			return
+		}
+		switch fl.Name {
+		case "title":
+			req.Title = *f.title
+		case "description":
+			req.Description = *f.description
+		case "status":
+			req.Status, err = parseStatus(*f.status)
+		case "due":
+			req.DueDate, err = parseDue(*f.due)
+		case "project":
+			// Tasks keep their project when the request has none
+			var id uuid.UUID
+			if id, err = uuid.FromString(*f.project); err != nil {
+				err = fmt.Errorf("%w: invalid project ID %q", errUsage, *f.project)
+				return
+			}
+			req.ProjectID = &id
+		case "label":
+			req.Labels = f.labels.values
+		}
+	})
+	return err
+}
+
+func runCreate(a *app, args []string) error {
+	fs := a.flagSet("create")
+	fields := registerTaskFlags(fs, string(entities.StatusPending))
+
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", errUsage, args)
+	}
+
+	req := entities.TaskRequest{Status: entities.StatusPending}
+	if err := fields.apply(fs, &req); err != nil {
+		return err
+	}
+	if req.Title == "" {
+		return fmt.Errorf("%w: --title is required", errUsage)
+	}
+
+	var task entities.TaskResponse
+	if err := a.client.do(http.MethodPost, "/api/tasks", nil, req, &task); err != nil {
+		return err
+	}
+
+	return printTask(a.stdout, a.profile.Output, &task)
+}
+
+func runUpdate(a *app, args []string) error {
+	fs := a.flagSet("update")
+	fields := registerTaskFlags(fs, "")
+
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := singleID(args)
+	if err != nil {
+		return err
+	}
+
+	// The API replaces the whole task, so start from its current state
+	current, err := a.getTask(id)
+	if err != nil {
+		return err
+	}
+	req := entities.TaskRequest{
+		Title:       current.Title,
+		Description: current.Description,
+		Status:      current.Status,
+		DueDate:     current.DueDate,
+		ProjectID:   current.ProjectID,
+		Labels:      current.Labels,
+	}
+	if err := fields.apply(fs, &req); err != nil {
+		return err
+	}
+
+	if err := a.client.do(http.MethodPut, "/api/tasks/"+id.String(), nil, req, nil); err != nil {
+		return err
+	}
+
+	task, err := a.getTask(id)
+	if err != nil {
+		return err
+	}
+	return printTask(a.stdout, a.profile.Output, task)
+}
+
+func runDelete(a *app, args []string) error {
+	args, err := a.parse(a.flagSet("delete"), args)
+	if err != nil {
+		return err
+	}
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := a.client.do(http.MethodDelete, "/api/tasks/"+id.String(), nil, nil, nil); err != nil {
+			return fmt.Errorf("deleting %s: %w", id, err)
+		}
+		fmt.Fprintln(a.stderr, "Deleted", id)
+	}
+	return nil
+}
+
+// runDone completes tasks with a single bulk request. Each task is completed
+// on its own, so one missing task does not hold back the others.
+func runDone(a *app, args []string) error {
+	args, err := a.parse(a.flagSet("done"), args)
+	if err != nil {
+		return err
+	}
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	atomic := false
+	req := entities.BulkRequest{Atomic: &atomic}
+	for i := range ids {
+		req.Items = append(req.Items, entities.BulkItem{Op: entities.BulkSetStatus, ID: &ids[i], Status: entities.StatusCompleted})
+	}
+
+	var resp entities.BulkResponse
+	if err := a.client.do(http.MethodPost, "/api/tasks/bulk", nil, req, &resp); err != nil {
+		return err
+	}
+
+	if a.profile.Output == "table" {
+		tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tRESULT\tERROR")
+		for _, result := range resp.Results {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", ids[result.Index], result.Status, result.Error)
+		}
+		tw.Flush()
+	} else if err := printValue(a.stdout, a.profile.Output, resp); err != nil {
+		return err
+	}
+
+	if resp.Failed > 0 {
+		return fmt.Errorf("%d of %d tasks could not be completed", resp.Failed, len(ids))
+	}
+	return nil
+}
+
+func (a *app) getTask(id uuid.UUID) (*entities.TaskResponse, error) {
+	var task entities.TaskResponse
+	query := url.Values{"include": {"labels"}}
+	if err := a.client.do(http.MethodGet, "/api/tasks/"+id.String(), query, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func singleID(args []string) (uuid.UUID, error) {
+	if len(args) != 1 {
+		return uuid.Nil, fmt.Errorf("%w: expected one task ID", errUsage)
+	}
+	ids, err := parseIDs(args)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return ids[0], nil
+}
+
+func parseIDs(args []string) ([]uuid.UUID, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: expected task IDs", errUsage)
+	}
+	ids := make([]uuid.UUID, len(args))
+	for i, arg := range args {
+		id, err := uuid.FromString(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid task ID %q", errUsage, arg)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
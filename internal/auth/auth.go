@@ -0,0 +1,37 @@
+// Package auth carries the identity of the caller through a request.
+//
+// The service does not authenticate users itself: it runs behind a gateway
+// that talks to the user service and forwards the caller's identity in the
+// X-User-ID and X-Project-ID headers.
+package auth
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// Caller is the user on whose behalf a request is made
+type Caller struct {
+	UserID    uuid.UUID
+	ProjectID *uuid.UUID
+}
+
+type contextKey struct{}
+
+// WithCaller returns a copy of ctx carrying the caller
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, contextKey{}, caller)
+}
+
+// FromContext returns the caller stored in ctx, if any
+func FromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(contextKey{}).(Caller)
+	return caller, ok
+}
+
+// CanSeeProject reports whether the caller has access to the given project.
+// Resources without a project are visible to everyone.
+func (c Caller) CanSeeProject(projectID *uuid.UUID) bool {
+	return projectID == nil || (c.ProjectID != nil && *c.ProjectID == *projectID)
+}
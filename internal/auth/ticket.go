@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Tickets issues and verifies short-lived tickets carrying a caller, for
+// clients that cannot send the gateway headers, such as browsers opening
+// WebSockets. A ticket is requested through the gateway, then passed in the
+// URL. Tickets are signed rather than stored, so every instance sharing the
+// secret accepts them.
+type Tickets struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// ticketClaims is the signed content of a ticket
+type ticketClaims struct {
+	UserID    uuid.UUID  `json:"u"`
+	ProjectID *uuid.UUID `json:"p,omitempty"`
+	ExpiresAt int64      `json:"e"`
+}
+
+// NewTickets returns tickets signed with secret and valid for ttl
+func NewTickets(secret []byte, ttl time.Duration) *Tickets {
+	return &Tickets{secret: secret, ttl: ttl, now: time.Now}
+}
+
+// Issue returns a ticket for the caller and the time it expires
+func (t *Tickets) Issue(caller Caller) (string, time.Time) {
+	expiresAt := t.now().Add(t.ttl).Truncate(time.Second)
+	payload, _ := json.Marshal(ticketClaims{UserID: caller.UserID, ProjectID: caller.ProjectID, ExpiresAt: expiresAt.Unix()})
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + t.sign(encoded), expiresAt
+}
+
+// Verify returns the caller of a ticket issued with the same secret that has
+// not expired yet
+func (t *Tickets) Verify(ticket string) (Caller, bool) {
+	encoded, signature, ok := strings.Cut(ticket, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(t.sign(encoded))) {
+		return Caller{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Caller{}, false
+	}
+	var claims ticketClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.UserID == uuid.Nil {
+		return Caller{}, false
+	}
+	if t.now().Unix() >= claims.ExpiresAt {
+		return Caller{}, false
+	}
+
+	return Caller{UserID: claims.UserID, ProjectID: claims.ProjectID}, true
+}
+
+func (t *Tickets) sign(encoded string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
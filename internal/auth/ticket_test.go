@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestTickets_Verify(t *testing.T) {
+	projectID := uuid.Must(uuid.NewV4())
+	caller := Caller{UserID: uuid.Must(uuid.NewV4()), ProjectID: &projectID}
+
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	tickets := NewTickets([]byte("secret"), 30*time.Second)
+	tickets.now = func() time.Time { return now }
+
+	ticket, expiresAt := tickets.Issue(caller)
+	if want := now.Add(30 * time.Second); !expiresAt.Equal(want) {
+		t.Errorf("Issue() expires at %v, want %v", expiresAt, want)
+	}
+
+	other := NewTickets([]byte("other secret"), 30*time.Second)
+	other.now = tickets.now
+
+	tests := []struct {
+		name    string
+		tickets *Tickets
+		ticket  string
+		after   time.Duration
+		wantOK  bool
+	}{
+		{name: "valid", tickets: tickets, ticket: ticket, wantOK: true},
+		{name: "expired", tickets: tickets, ticket: ticket, after: 30 * time.Second},
+		{name: "other secret", tickets: other, ticket: ticket},
+		{name: "tampered", tickets: tickets, ticket: "x" + ticket},
+		{name: "malformed", tickets: tickets, ticket: "ticket"},
+		{name: "empty", tickets: tickets},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC).Add(tt.after)
+
+			got, ok := tt.tickets.Verify(tt.ticket)
+			if ok != tt.wantOK {
+				t.Fatalf("Verify() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (got.UserID != caller.UserID || got.ProjectID == nil || *got.ProjectID != projectID) {
+				t.Errorf("Verify() = %+v, want %+v", got, caller)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package collab
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"task-management/internal/auth"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// writeWait bounds writing a message to the connection
+	writeWait = 10 * time.Second
+
+	// pongWait is how long the connection may stay silent. The server pings
+	// every pingPeriod, which must be shorter.
+	pongWait   = 60 * time.Second
+	pingPeriod = 30 * time.Second
+
+	maxMessageSize = 4096
+
+	// sendBuffer is the number of messages a client may lag behind before it
+	// is disconnected
+	sendBuffer = 64
+)
+
+// client is a WebSocket connection. topics maps each subscribed topic to
+// whether the client is editing it and is guarded by the hub mutex.
+type client struct {
+	hub    *Hub
+	conn   *websocket.Conn
+	caller auth.Caller
+	topics map[topic]bool
+
+	out       chan Outbound
+	closeOnce sync.Once
+	done      chan struct{}
+	// closeCode is sent in the close frame, it is set before done is closed
+	closeCode int
+}
+
+func newClient(h *Hub, conn *websocket.Conn, caller auth.Caller) *client {
+	return &client{
+		hub:    h,
+		conn:   conn,
+		caller: caller,
+		topics: make(map[topic]bool),
+		out:    make(chan Outbound, sendBuffer),
+		done:   make(chan struct{}),
+	}
+}
+
+// send queues a message, disconnecting the client if it cannot keep up
+func (c *client) send(msg Outbound) {
+	select {
+	case <-c.done:
+	case c.out <- msg:
+	default:
+		c.close()
+	}
+}
+
+// close stops the write pump, which closes the connection
+func (c *client) close() {
+	c.closeWith(websocket.CloseNormalClosure)
+}
+
+// closeWith is close sending the given close code, the first call wins
+func (c *client) closeWith(code int) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		close(c.done)
+	})
+}
+
+// readPump handles incoming messages until the connection fails or closes
+func (c *client) readPump() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		c.close()
+		c.hub.disconnect(c)
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		// Any message shows the client is alive
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var msg Inbound
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.send(Outbound{Type: MsgError, Error: "invalid message"})
+			continue
+		}
+		c.hub.handle(ctx, c, msg)
+	}
+}
+
+// writePump sends queued messages and pings until the client is closed
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(c.closeCode, ""))
+			return
+		case msg := <-c.out:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
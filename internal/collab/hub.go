@@ -0,0 +1,361 @@
+// Package collab runs the WebSocket channel used by boards for presence,
+// editing indicators and live task updates.
+package collab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/stream"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// maxTopics bounds the subscriptions of a single connection
+const maxTopics = 50
+
+// TaskGetter looks up tasks to check access to task topics. It is satisfied
+// by services.Service.
+type TaskGetter interface {
+	GetTaskByID(ctx context.Context, id uuid.UUID, include entities.TaskIncludes) (*entities.TaskResponse, error)
+}
+
+// Hub tracks the connected clients, their subscriptions and presence.
+// Presence is kept per server instance; task updates reach every instance
+// through the event stream.
+type Hub struct {
+	tasks    TaskGetter
+	tickets  *auth.Tickets
+	upgrader websocket.Upgrader
+
+	mu     sync.Mutex
+	topics map[topic]map[*client]struct{}
+	conns  map[*client]struct{}
+	closed bool
+}
+
+// NewHub creates a hub accepting connections from the given browser origins
+// only, identified by the gateway or by one of tickets
+func NewHub(tasks TaskGetter, allowedOrigins []string, tickets *auth.Tickets) *Hub {
+	origins := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		origins[origin] = true
+	}
+
+	return &Hub{
+		tasks:   tasks,
+		tickets: tickets,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin: func(r *http.Request) bool {
+				return origins[r.Header.Get("Origin")]
+			},
+		},
+		topics: make(map[topic]map[*client]struct{}),
+		conns:  make(map[*client]struct{}),
+	}
+}
+
+// IssueTicket returns a ticket identifying the caller when connecting, for
+// browsers which cannot send the gateway headers on WebSockets
+func (h *Hub) IssueTicket(caller auth.Caller) (string, time.Time) {
+	return h.tickets.Issue(caller)
+}
+
+// Caller returns the caller connecting with r: the one of the ticket query
+// parameter when there is one, the one forwarded by the gateway otherwise
+func (h *Hub) Caller(r *http.Request) (auth.Caller, bool) {
+	if ticket := r.URL.Query().Get("ticket"); ticket != "" {
+		return h.tickets.Verify(ticket)
+	}
+	return auth.FromContext(r.Context())
+}
+
+// Serve upgrades the request to a WebSocket connection for the caller and
+// handles it until it is closed
+func (h *Hub) Serve(w http.ResponseWriter, r *http.Request, caller auth.Caller) {
+	h.mu.Lock()
+	closed := h.closed
+	h.mu.Unlock()
+	if closed {
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already answered the request
+		return
+	}
+
+	c := newClient(h, conn, caller)
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		c.closeWith(websocket.CloseGoingAway)
+		c.writePump()
+		return
+	}
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+
+	go c.writePump()
+	c.readPump()
+}
+
+// Shutdown closes every connection with a going away close frame, telling
+// clients to reconnect, and refuses new ones. Connections are hijacked from
+// the HTTP server, which does not wait for them when shutting down.
+func (h *Hub) Shutdown() {
+	h.mu.Lock()
+	h.closed = true
+	clients := make([]*client, 0, len(h.conns))
+	for c := range h.conns {
+		clients = append(clients, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range clients {
+		c.closeWith(websocket.CloseGoingAway)
+	}
+}
+
+// Run passes the task events of the broker to the subscribers of the task
+// and of its project until ctx is cancelled
+func (h *Hub) Run(ctx context.Context, broker *stream.Broker) {
+	all := func(stream.Event) bool { return true }
+
+	for {
+		sub, _, _ := broker.Subscribe(nil, all)
+		for done := false; !done; {
+			select {
+			case <-ctx.Done():
+				sub.Close()
+				return
+			case event, ok := <-sub.C:
+				if !ok {
+					// Dropped for lagging behind or reset, subscribe again
+					done = true
+					break
+				}
+				h.broadcastEvent(event)
+			}
+		}
+	}
+}
+
+// handle processes a message received from a client
+func (h *Hub) handle(ctx context.Context, c *client, msg Inbound) {
+	switch msg.Type {
+	case MsgPing:
+		c.send(Outbound{Type: MsgPong})
+
+	case MsgSubscribe:
+		t, err := h.authorize(ctx, c.caller, msg.Topic)
+		if err != nil {
+			c.send(Outbound{Type: MsgError, Topic: msg.Topic, Error: err.Error()})
+			return
+		}
+		if !h.subscribe(c, t) {
+			c.send(Outbound{Type: MsgError, Topic: msg.Topic, Error: "too many subscriptions"})
+			return
+		}
+		c.send(Outbound{Type: MsgSubscribed, Topic: t.String(), Users: h.presence(t)})
+		h.broadcastPresence(t, c)
+
+	case MsgUnsubscribe, MsgEditing:
+		t, err := parseTopic(msg.Topic)
+		if err != nil {
+			c.send(Outbound{Type: MsgError, Topic: msg.Topic, Error: err.Error()})
+			return
+		}
+		var changed bool
+		if msg.Type == MsgUnsubscribe {
+			changed = h.unsubscribe(c, t)
+			c.send(Outbound{Type: MsgUnsubscribed, Topic: t.String()})
+		} else {
+			changed = h.setEditing(c, t, msg.Editing)
+		}
+		if changed {
+			h.broadcastPresence(t, nil)
+		}
+
+	default:
+		c.send(Outbound{Type: MsgError, Error: "unknown message type " + msg.Type})
+	}
+}
+
+// authorize parses a topic and checks that the caller may see it
+func (h *Hub) authorize(ctx context.Context, caller auth.Caller, raw string) (topic, error) {
+	t, err := parseTopic(raw)
+	if err != nil {
+		return t, err
+	}
+
+	var projectID *uuid.UUID
+	if t.kind == topicProject {
+		projectID = &t.id
+	} else {
+		task, err := h.tasks.GetTaskByID(auth.WithCaller(ctx, caller), t.id, entities.TaskIncludes{})
+		if err != nil {
+			// Missing tasks and lookup failures alike stay opaque to clients
+			return t, entities.ErrTaskNotFound
+		}
+		projectID = task.ProjectID
+	}
+
+	if !caller.CanSeeProject(projectID) {
+		return t, errors.New("not allowed to subscribe to " + raw)
+	}
+
+	return t, nil
+}
+
+func (h *Hub) subscribe(c *client, t topic) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := c.topics[t]; !ok && len(c.topics) >= maxTopics {
+		return false
+	}
+
+	clients, ok := h.topics[t]
+	if !ok {
+		clients = make(map[*client]struct{})
+		h.topics[t] = clients
+	}
+	clients[c] = struct{}{}
+	if _, ok := c.topics[t]; !ok {
+		c.topics[t] = false
+	}
+
+	return true
+}
+
+// unsubscribe removes c from t and reports whether it was subscribed
+func (h *Hub) unsubscribe(c *client, t topic) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.remove(c, t)
+}
+
+// setEditing records whether c is editing t and reports whether it changed
+func (h *Hub) setEditing(c *client, t topic, editing bool) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	current, ok := c.topics[t]
+	if !ok || current == editing {
+		return false
+	}
+	c.topics[t] = editing
+	return true
+}
+
+// disconnect removes c from every topic and tells the remaining viewers
+func (h *Hub) disconnect(c *client) {
+	h.mu.Lock()
+	delete(h.conns, c)
+	var left []topic
+	for t := range c.topics {
+		h.remove(c, t)
+		left = append(left, t)
+	}
+	h.mu.Unlock()
+
+	for _, t := range left {
+		h.broadcastPresence(t, nil)
+	}
+}
+
+// remove unsubscribes c from t. h.mu must be held.
+func (h *Hub) remove(c *client, t topic) bool {
+	if _, ok := c.topics[t]; !ok {
+		return false
+	}
+	delete(c.topics, t)
+
+	clients := h.topics[t]
+	delete(clients, c)
+	if len(clients) == 0 {
+		delete(h.topics, t)
+	}
+	return true
+}
+
+// presence lists the users viewing t, editing if any of their connections is
+func (h *Hub) presence(t topic) []Presence {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	editing := make(map[uuid.UUID]bool)
+	for c := range h.topics[t] {
+		editing[c.caller.UserID] = editing[c.caller.UserID] || c.topics[t]
+	}
+
+	users := make([]Presence, 0, len(editing))
+	for userID, e := range editing {
+		users = append(users, Presence{UserID: userID, Editing: e})
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserID.String() < users[j].UserID.String()
+	})
+
+	return users
+}
+
+// broadcastPresence sends the viewers of t to every one of them but skip,
+// which already got them in its subscribed message
+func (h *Hub) broadcastPresence(t topic, skip *client) {
+	msg := Outbound{Type: MsgPresence, Topic: t.String(), Users: h.presence(t)}
+	for _, c := range h.clients(t) {
+		if c != skip {
+			c.send(msg)
+		}
+	}
+}
+
+// broadcastEvent sends a task event to the subscribers of the task and of
+// its project that can see it
+func (h *Hub) broadcastEvent(event stream.Event) {
+	var task entities.TaskEvent
+	if err := json.Unmarshal(event.Data, &task); err != nil {
+		return
+	}
+
+	targets := []topic{{kind: topicTask, id: task.TaskID}}
+	if event.ProjectID != nil {
+		targets = append(targets, topic{kind: topicProject, id: *event.ProjectID})
+	}
+
+	for _, t := range targets {
+		msg := Outbound{Type: MsgEvent, Topic: t.String(), Event: event.Data}
+		for _, c := range h.clients(t) {
+			if c.caller.CanSeeProject(event.ProjectID) {
+				c.send(msg)
+			}
+		}
+	}
+}
+
+// clients returns a snapshot of the subscribers of t
+func (h *Hub) clients(t topic) []*client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	clients := make([]*client, 0, len(h.topics[t]))
+	for c := range h.topics[t] {
+		clients = append(clients, c)
+	}
+	return clients
+}
@@ -0,0 +1,259 @@
+package collab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/stream"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// testOrigin is the browser origin the test hubs accept
+const testOrigin = "https://board.example"
+
+type taskGetter map[uuid.UUID]*entities.TaskResponse
+
+func (g taskGetter) GetTaskByID(ctx context.Context, id uuid.UUID, include entities.TaskIncludes) (*entities.TaskResponse, error) {
+	if task, ok := g[id]; ok {
+		return task, nil
+	}
+	return nil, entities.ErrTaskNotFound
+}
+
+// newTestServer serves the hub, taking the caller from the X-User-ID and
+// X-Project-ID headers like the router does
+func newTestServer(t *testing.T, h *Hub) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller := auth.Caller{UserID: uuid.FromStringOrNil(r.Header.Get("X-User-ID"))}
+		if projectID, err := uuid.FromString(r.Header.Get("X-Project-ID")); err == nil {
+			caller.ProjectID = &projectID
+		}
+		h.Serve(w, r, caller)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func dial(t *testing.T, srv *httptest.Server, userID uuid.UUID, projectID *uuid.UUID) *websocket.Conn {
+	header := http.Header{"Origin": {testOrigin}, "X-User-ID": {userID.String()}}
+	if projectID != nil {
+		header.Set("X-Project-ID", projectID.String())
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), header)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func newHub(tasks TaskGetter) *Hub {
+	return NewHub(tasks, []string{testOrigin}, auth.NewTickets([]byte("secret"), time.Minute))
+}
+
+func send(t *testing.T, conn *websocket.Conn, msg Inbound) {
+	if err := conn.WriteJSON(msg); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+}
+
+// expect reads messages until one of the given type arrives
+func expect(t *testing.T, conn *websocket.Conn, msgType string) Outbound {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		var msg Outbound
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("waiting for %s: %v", msgType, err)
+		}
+		if msg.Type == msgType {
+			return msg
+		}
+	}
+}
+
+func TestHub_presence(t *testing.T) {
+	projectID := uuid.Must(uuid.NewV4())
+	taskID := uuid.Must(uuid.NewV4())
+	alice, bob := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	topic := "task:" + taskID.String()
+
+	h := newHub(taskGetter{taskID: {ID: taskID, ProjectID: &projectID}})
+	srv := newTestServer(t, h)
+
+	a := dial(t, srv, alice, &projectID)
+	send(t, a, Inbound{Type: MsgSubscribe, Topic: topic})
+	if msg := expect(t, a, MsgSubscribed); len(msg.Users) != 1 || msg.Users[0].UserID != alice {
+		t.Fatalf("subscribed users = %v, want only alice", msg.Users)
+	}
+
+	b := dial(t, srv, bob, &projectID)
+	send(t, b, Inbound{Type: MsgSubscribe, Topic: topic})
+	expect(t, b, MsgSubscribed)
+	if msg := expect(t, a, MsgPresence); len(msg.Users) != 2 {
+		t.Fatalf("presence after join = %v, want two users", msg.Users)
+	}
+
+	send(t, b, Inbound{Type: MsgEditing, Topic: topic, Editing: true})
+	msg := expect(t, a, MsgPresence)
+	for _, user := range msg.Users {
+		if user.Editing != (user.UserID == bob) {
+			t.Fatalf("presence while editing = %v, want only bob editing", msg.Users)
+		}
+	}
+
+	b.Close()
+	if msg := expect(t, a, MsgPresence); len(msg.Users) != 1 || msg.Users[0].UserID != alice {
+		t.Fatalf("presence after disconnect = %v, want only alice", msg.Users)
+	}
+
+	send(t, a, Inbound{Type: MsgPing})
+	expect(t, a, MsgPong)
+}
+
+func TestHub_access(t *testing.T) {
+	projectID := uuid.Must(uuid.NewV4())
+	otherProject := uuid.Must(uuid.NewV4())
+	taskID := uuid.Must(uuid.NewV4())
+
+	h := newHub(taskGetter{taskID: {ID: taskID, ProjectID: &otherProject}})
+	srv := newTestServer(t, h)
+	conn := dial(t, srv, uuid.Must(uuid.NewV4()), &projectID)
+
+	topics := []string{
+		"project:" + otherProject.String(),
+		"task:" + taskID.String(),
+		"task:" + uuid.Must(uuid.NewV4()).String(),
+		"board:1",
+	}
+	for _, topic := range topics {
+		send(t, conn, Inbound{Type: MsgSubscribe, Topic: topic})
+		if msg := expect(t, conn, MsgError); msg.Topic != topic {
+			t.Errorf("error topic = %q, want %q", msg.Topic, topic)
+		}
+	}
+}
+
+func TestHub_events(t *testing.T) {
+	projectID := uuid.Must(uuid.NewV4())
+	taskID := uuid.Must(uuid.NewV4())
+
+	broker := stream.NewBroker(stream.DefaultLogSize)
+	h := newHub(taskGetter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.Run(ctx, broker)
+
+	srv := newTestServer(t, h)
+	conn := dial(t, srv, uuid.Must(uuid.NewV4()), &projectID)
+	send(t, conn, Inbound{Type: MsgSubscribe, Topic: "project:" + projectID.String()})
+	expect(t, conn, MsgSubscribed)
+
+	payload, _ := json.Marshal(entities.TaskEvent{
+		ID:        uuid.Must(uuid.NewV4()),
+		Type:      entities.EventTaskUpdated,
+		TaskID:    taskID,
+		ProjectID: &projectID,
+	})
+	event, err := stream.NewEvent(1, payload)
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+	broker.Publish(event)
+
+	msg := expect(t, conn, MsgEvent)
+	var got entities.TaskEvent
+	if err := json.Unmarshal(msg.Event, &got); err != nil || got.TaskID != taskID {
+		t.Errorf("event = %s, want task %s", msg.Event, taskID)
+	}
+}
+
+func TestHub_Shutdown(t *testing.T) {
+	h := newHub(taskGetter{})
+	srv := newTestServer(t, h)
+
+	conn := dial(t, srv, uuid.Must(uuid.NewV4()), nil)
+	send(t, conn, Inbound{Type: MsgPing})
+	expect(t, conn, MsgPong)
+
+	h.Shutdown()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Errorf("ReadMessage() error = %v, want a going away close", err)
+	}
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), http.Header{"Origin": {testOrigin}})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Dial() after Shutdown = %v, want %d", err, http.StatusServiceUnavailable)
+	}
+}
+
+func TestHub_origin(t *testing.T) {
+	srv := newTestServer(t, newHub(taskGetter{}))
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	tests := []struct {
+		name   string
+		header http.Header
+	}{
+		{name: "other origin", header: http.Header{"Origin": {"https://evil.example"}}},
+		{name: "no origin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, resp, err := websocket.DefaultDialer.Dial(url, tt.header)
+			if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+				t.Errorf("Dial() = %v, want %d", err, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestHub_Caller(t *testing.T) {
+	h := newHub(taskGetter{})
+	ticketCaller := auth.Caller{UserID: uuid.Must(uuid.NewV4())}
+	ticket, _ := h.IssueTicket(ticketCaller)
+	gatewayCaller := auth.Caller{UserID: uuid.Must(uuid.NewV4())}
+
+	tests := []struct {
+		name    string
+		target  string
+		gateway bool
+		want    auth.Caller
+		wantOK  bool
+	}{
+		{name: "ticket", target: "/api/ws?ticket=" + ticket, want: ticketCaller, wantOK: true},
+		{name: "ticket over gateway identity", target: "/api/ws?ticket=" + ticket, gateway: true, want: ticketCaller, wantOK: true},
+		{name: "invalid ticket", target: "/api/ws?ticket=forged", gateway: true},
+		{name: "gateway identity", target: "/api/ws", gateway: true, want: gatewayCaller, wantOK: true},
+		{name: "anonymous", target: "/api/ws"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			if tt.gateway {
+				r = r.WithContext(auth.WithCaller(r.Context(), gatewayCaller))
+			}
+
+			got, ok := h.Caller(r)
+			if ok != tt.wantOK || got.UserID != tt.want.UserID {
+				t.Errorf("Hub.Caller() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package collab
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// Message types sent by clients
+const (
+	MsgSubscribe   = "subscribe"
+	MsgUnsubscribe = "unsubscribe"
+	MsgEditing     = "editing"
+	MsgPing        = "ping"
+)
+
+// Message types sent by the server
+const (
+	MsgSubscribed   = "subscribed"
+	MsgUnsubscribed = "unsubscribed"
+	MsgPresence     = "presence"
+	MsgEvent        = "event"
+	MsgPong         = "pong"
+	MsgError        = "error"
+)
+
+// Inbound is a message sent by a client. Topics are "task:<id>" or
+// "project:<id>".
+type Inbound struct {
+	Type    string `json:"type"`
+	Topic   string `json:"topic,omitempty"`
+	Editing bool   `json:"editing,omitempty"`
+}
+
+// Outbound is a message sent to a client
+type Outbound struct {
+	Type  string          `json:"type"`
+	Topic string          `json:"topic,omitempty"`
+	Users []Presence      `json:"users,omitempty"`
+	Event json.RawMessage `json:"event,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// Presence describes a user viewing a topic
+type Presence struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Editing bool      `json:"editing"`
+}
+
+// topic is a parsed subscription topic
+type topic struct {
+	kind string
+	id   uuid.UUID
+}
+
+const (
+	topicTask    = "task"
+	topicProject = "project"
+)
+
+func (t topic) String() string {
+	return t.kind + ":" + t.id.String()
+}
+
+// parseTopic parses a "task:<id>" or "project:<id>" topic
+func parseTopic(raw string) (topic, error) {
+	kind, rawID, _ := strings.Cut(raw, ":")
+	if kind != topicTask && kind != topicProject {
+		return topic{}, fmt.Errorf("invalid topic %q, expected task:<id> or project:<id>", raw)
+	}
+
+	id, err := uuid.FromString(rawID)
+	if err != nil {
+		return topic{}, fmt.Errorf("invalid topic %q: %v", raw, err)
+	}
+
+	return topic{kind: kind, id: id}, nil
+}
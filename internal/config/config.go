@@ -0,0 +1,400 @@
+// Package config loads the settings of the binaries into a typed struct.
+// Values come from the defaults below, then an optional YAML file, then the
+// environment, each overriding the previous one. A .env file in the working
+// directory is loaded into the environment outside of deployed environments.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds every setting. Fields are named after their YAML keys and
+// carry the environment variable overriding them.
+type Config struct {
+	// Env names the deployment, prod switches the default CORS origins
+	Env string `yaml:"env" env:"ENV"`
+	// ShutdownTimeout bounds draining connections and stopping workers on
+	// SIGTERM. It should stay below the grace period of the orchestrator.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" validate:"min=0"`
+
+	HTTP      HTTP      `yaml:"http"`
+	Service   Service   `yaml:"service"`
+	GRPC      GRPC      `yaml:"grpc"`
+	CORS      CORS      `yaml:"cors"`
+	Collab    Collab    `yaml:"collab"`
+	Database  Database  `yaml:"database"`
+	Log       Log       `yaml:"log"`
+	Outbox    Outbox    `yaml:"outbox"`
+	Health    Health    `yaml:"health"`
+	Tracing   Tracing   `yaml:"tracing"`
+	RateLimit RateLimit `yaml:"rate_limit"`
+}
+
+// HTTP configures the REST, GraphQL and streaming server
+type HTTP struct {
+	Port              int           `yaml:"port" env:"PORT" validate:"min=1,max=65535"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" validate:"min=0"`
+	// ReadTimeout and WriteTimeout are lifted by the handlers of event
+	// streams, WebSockets, exports and calendar feeds
+	ReadTimeout  time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" validate:"min=0"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" validate:"min=0"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" validate:"min=0"`
+	// RequestTimeout bounds the work done for a request, database queries
+	// included. Event streams, WebSockets, exports and calendar feeds are
+	// left out. 0 disables it.
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"HTTP_REQUEST_TIMEOUT" validate:"min=0"`
+	// MaxImportSize bounds the size of uploaded import files, in bytes
+	MaxImportSize int `yaml:"max_import_size" env:"HTTP_MAX_IMPORT_SIZE" validate:"min=1"`
+}
+
+// Service configures the task service behind every API
+type Service struct {
+	// ImportBatchSize is the number of imported tasks inserted per statement
+	ImportBatchSize int `yaml:"import_batch_size" env:"IMPORT_BATCH_SIZE" validate:"min=1"`
+	// ExportChunkSize is the number of exported tasks whose labels are
+	// loaded at once
+	ExportChunkSize int `yaml:"export_chunk_size" env:"EXPORT_CHUNK_SIZE" validate:"min=1"`
+}
+
+// GRPC configures the gRPC server
+type GRPC struct {
+	Port int `yaml:"port" env:"GRPC_PORT" validate:"min=1,max=65535"`
+}
+
+// CORS lists the browser origins allowed to call the API and open
+// WebSockets
+type CORS struct {
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" validate:"dive,required"`
+}
+
+// Collab configures the WebSocket channel of boards
+type Collab struct {
+	// TicketSecret signs the tickets browsers open WebSockets with and must
+	// be shared by every instance. When empty, each instance generates its
+	// own and only accepts the tickets it issued.
+	TicketSecret string        `yaml:"ticket_secret" env:"COLLAB_TICKET_SECRET"`
+	TicketTTL    time.Duration `yaml:"ticket_ttl" env:"COLLAB_TICKET_TTL" validate:"min=1"`
+}
+
+// Database configures the Postgres connection pool
+type Database struct {
+	URL             string        `yaml:"url" env:"DATABASE_URL" validate:"required"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" validate:"min=0,ltefield=MaxOpenConns"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" validate:"min=1"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" validate:"min=0"`
+	// Migrate applies the pending migrations when connecting
+	Migrate bool `yaml:"migrate" env:"DB_MIGRATE"`
+}
+
+// Log configures logging
+type Log struct {
+	Level string `yaml:"level" env:"LOG_LEVEL" validate:"oneof=debug info warn error"`
+	// Format is json, or text for reading logs in a terminal
+	Format string `yaml:"format" env:"LOG_FORMAT" validate:"oneof=json text"`
+	// QueryLevel selects the logged queries: debug logs them all, warn the
+	// failed and slow ones, error the failed ones, silent none
+	QueryLevel string `yaml:"query_level" env:"LOG_QUERY_LEVEL" validate:"oneof=debug warn error silent"`
+	// SlowQueryThreshold is the duration above which a query is slow, 0
+	// disables it
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold" env:"LOG_SLOW_QUERY_THRESHOLD" validate:"min=0"`
+}
+
+// Outbox configures the publishers fed by the outbox relay, in addition to
+// webhooks which are always fed
+type Outbox struct {
+	// Log logs every event
+	Log bool `yaml:"log" env:"OUTBOX_LOG"`
+	// HTTPURL receives batches of events when set
+	HTTPURL string `yaml:"http_url" env:"OUTBOX_HTTP_URL" validate:"omitempty,url"`
+	// NATSURL receives events on NATSSubject.<type> when set
+	NATSURL     string `yaml:"nats_url" env:"OUTBOX_NATS_URL" validate:"omitempty,url"`
+	NATSSubject string `yaml:"nats_subject" env:"OUTBOX_NATS_SUBJECT" validate:"required"`
+}
+
+// Health configures the probes and the status page
+type Health struct {
+	// Timeout bounds the dependency checks of a probe
+	Timeout time.Duration `yaml:"timeout" env:"HEALTH_TIMEOUT" validate:"min=1"`
+	// StatusToken is required as a bearer token by /status and /metrics.
+	// Both are disabled while it is empty.
+	StatusToken string `yaml:"status_token" env:"STATUS_TOKEN"`
+}
+
+// Tracing configures OpenTelemetry tracing
+type Tracing struct {
+	// Exporter is where spans go: none, stdout, or otlp over HTTP
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER" validate:"oneof=none stdout otlp"`
+	// Endpoint is the full URL spans are sent to, path included, such as
+	// http://localhost:4318/v1/traces. When empty, the exporter reads the
+	// standard OTEL_EXPORTER_OTLP_* variables and falls back to
+	// http://localhost:4318/v1/traces.
+	Endpoint    string `yaml:"endpoint" env:"TRACING_ENDPOINT" validate:"omitempty,url"`
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME" validate:"required"`
+	// SampleRatio is the share of traces started here that are recorded.
+	// Traces started upstream follow the sampling decision of the caller.
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" validate:"min=0,max=1"`
+}
+
+// RateLimit configures the request quotas of the REST and GraphQL APIs
+type RateLimit struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// Store keeps the buckets in memory, per instance, or in postgres,
+	// shared by every instance
+	Store string `yaml:"store" env:"RATE_LIMIT_STORE" validate:"oneof=memory postgres"`
+	// TrustForwardedFor takes the client IP from the last X-Forwarded-For
+	// entry, set it only behind a proxy that appends to the header
+	TrustForwardedFor bool `yaml:"trust_forwarded_for" env:"RATE_LIMIT_TRUST_FORWARDED_FOR"`
+	// TrustUserID gives the callers identified by X-User-ID a bucket of
+	// their own on top of their IP's. Set it only behind a gateway that
+	// authenticates callers and overwrites the header.
+	TrustUserID bool `yaml:"trust_user_id" env:"RATE_LIMIT_TRUST_USER_ID"`
+	// Rules are matched in order, the first one matching a request applies
+	Rules []RateLimitRule `yaml:"rules" validate:"dive"`
+}
+
+// RateLimitRule sets the quotas of a group of routes. Every request draws
+// from its IP's bucket, trusted identified callers from their own too.
+type RateLimitRule struct {
+	Name string `yaml:"name" validate:"required"`
+	// Methods and Routes, route templates such as /api/tasks/{id}, restrict
+	// the rule when set
+	Methods []string `yaml:"methods"`
+	Routes  []string `yaml:"routes"`
+	IP      Quota    `yaml:"ip"`
+	User    Quota    `yaml:"user"`
+}
+
+// Quota allows Requests per period on average, and bursts of up to Burst
+// requests. Zero requests leaves the callers unlimited.
+type Quota struct {
+	Requests int           `yaml:"requests" validate:"min=0"`
+	Per      time.Duration `yaml:"per" validate:"required_with=Requests,min=0"`
+	// Burst defaults to Requests
+	Burst int `yaml:"burst" validate:"min=0"`
+}
+
+// Default returns the configuration used when nothing overrides it
+func Default() Config {
+	return Config{
+		ShutdownTimeout: 20 * time.Second,
+		HTTP: HTTP{
+			Port:              8080,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      time.Minute,
+			IdleTimeout:       2 * time.Minute,
+			RequestTimeout:    30 * time.Second,
+			MaxImportSize:     10 << 20,
+		},
+		Service: Service{
+			ImportBatchSize: 500,
+			ExportChunkSize: 200,
+		},
+		GRPC: GRPC{
+			Port: 9090,
+		},
+		Collab: Collab{
+			TicketTTL: time.Minute,
+		},
+		Database: Database{
+			MaxIdleConns:    10,
+			MaxOpenConns:    100,
+			ConnMaxLifetime: time.Hour,
+			Migrate:         true,
+		},
+		Log: Log{
+			Level:              "info",
+			Format:             "json",
+			QueryLevel:         "warn",
+			SlowQueryThreshold: 200 * time.Millisecond,
+		},
+		Outbox: Outbox{
+			NATSSubject: "tasks",
+		},
+		Health: Health{
+			Timeout: 2 * time.Second,
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			ServiceName: "task-management",
+			SampleRatio: 1,
+		},
+		RateLimit: RateLimit{
+			Enabled: true,
+			Store:   "memory",
+			Rules: []RateLimitRule{
+				{
+					Name:    "create",
+					Methods: []string{"POST"},
+					Routes:  []string{"/api/tasks", "/api/tasks/bulk", "/api/tasks/import"},
+					IP:      Quota{Requests: 30, Per: time.Minute, Burst: 10},
+					User:    Quota{Requests: 60, Per: time.Minute, Burst: 20},
+				},
+				{
+					Name: "default",
+					IP:   Quota{Requests: 300, Per: time.Minute, Burst: 100},
+					User: Quota{Requests: 600, Per: time.Minute, Burst: 200},
+				},
+			},
+		},
+	}
+}
+
+// Load reads the configuration from the YAML file at path, if any, and the
+// environment, then validates it. path defaults to CONFIG_FILE.
+func Load(path string) (*Config, error) {
+	if os.Getenv("ENV") == "" {
+		if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to load .env: %w", err)
+		}
+	}
+
+	cfg := Default()
+
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := decodeFile(path, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(&cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.CORS.AllowedOrigins) == 0 {
+		cfg.CORS.AllowedOrigins = []string{"http://localhost:3000"}
+		if cfg.Env == "prod" {
+			cfg.CORS.AllowedOrigins = []string{"https://task-management-frontend.vercel.app"}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// decodeFile reads the YAML file at path into cfg. Unknown keys are errors,
+// so a misspelled setting does not silently keep its default.
+func decodeFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	// An empty file leaves the defaults
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports the first invalid setting by its YAML key
+func (c *Config) Validate() error {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("yaml"), ",", 2)[0]
+	})
+
+	err := validate.Struct(c)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) && len(errs) > 0 {
+		e := errs[0]
+		key := strings.TrimPrefix(e.Namespace(), "Config.")
+		if e.Param() != "" {
+			return fmt.Errorf("invalid configuration: %s must satisfy %s=%s", key, e.Tag(), e.Param())
+		}
+		return fmt.Errorf("invalid configuration: %s must satisfy %s", key, e.Tag())
+	}
+	return err
+}
+
+// applyEnv sets the fields of v tagged with a set environment variable
+func applyEnv(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnv(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := field.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		raw, ok := os.LookupEnv(name)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := setValue(value, raw); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setValue parses raw into v. Lists are comma separated.
+func setValue(v reflect.Value, raw string) error {
+	switch v.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case string:
+		v.SetString(raw)
+	case int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case []string:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		v.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
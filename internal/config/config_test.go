@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("ENV", "test")
+		t.Setenv("CONFIG_FILE", "")
+		t.Setenv("DATABASE_URL", "postgres://localhost/tasks")
+
+		cfg, err := Load("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.HTTP.Port != 8080 || cfg.GRPC.Port != 9090 {
+			t.Errorf("ports = %d, %d, want 8080, 9090", cfg.HTTP.Port, cfg.GRPC.Port)
+		}
+		if cfg.Database.MaxOpenConns != 100 || cfg.Database.ConnMaxLifetime != time.Hour || !cfg.Database.Migrate {
+			t.Errorf("database = %+v", cfg.Database)
+		}
+		if want := []string{"http://localhost:3000"}; !reflect.DeepEqual(cfg.CORS.AllowedOrigins, want) {
+			t.Errorf("origins = %v, want %v", cfg.CORS.AllowedOrigins, want)
+		}
+	})
+
+	t.Run("environment overrides file", func(t *testing.T) {
+		path := writeConfig(t, `
+http:
+  port: 8000
+  read_header_timeout: 5s
+database:
+  url: postgres://file/tasks
+  max_open_conns: 20
+  max_idle_conns: 5
+log:
+  level: warn
+`)
+		t.Setenv("ENV", "test")
+		t.Setenv("DATABASE_URL", "")
+		t.Setenv("PORT", "8081")
+		t.Setenv("DB_MIGRATE", "false")
+		t.Setenv("CORS_ALLOWED_ORIGINS", "https://a.example, https://b.example")
+
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.HTTP.Port != 8081 {
+			t.Errorf("port = %d, want the environment's 8081", cfg.HTTP.Port)
+		}
+		if cfg.HTTP.ReadHeaderTimeout != 5*time.Second {
+			t.Errorf("read header timeout = %s, want 5s", cfg.HTTP.ReadHeaderTimeout)
+		}
+		if cfg.Database.URL != "postgres://file/tasks" || cfg.Database.MaxOpenConns != 20 || cfg.Database.Migrate {
+			t.Errorf("database = %+v", cfg.Database)
+		}
+		if cfg.Log.Level != "warn" {
+			t.Errorf("log level = %s, want warn", cfg.Log.Level)
+		}
+		if want := []string{"https://a.example", "https://b.example"}; !reflect.DeepEqual(cfg.CORS.AllowedOrigins, want) {
+			t.Errorf("origins = %v, want %v", cfg.CORS.AllowedOrigins, want)
+		}
+	})
+
+	t.Run("example file", func(t *testing.T) {
+		t.Setenv("ENV", "test")
+		t.Setenv("DATABASE_URL", "")
+
+		if _, err := Load("../../config.example.yaml"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("unknown key in file", func(t *testing.T) {
+		path := writeConfig(t, `
+http:
+  prot: 8000
+`)
+		t.Setenv("ENV", "test")
+		t.Setenv("DATABASE_URL", "postgres://localhost/tasks")
+
+		_, err := Load(path)
+		if err == nil || !strings.Contains(err.Error(), "prot") {
+			t.Errorf("Load() error = %v, want it to reject the misspelled key", err)
+		}
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		path := writeConfig(t, "")
+		t.Setenv("ENV", "test")
+		t.Setenv("DATABASE_URL", "postgres://localhost/tasks")
+
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.HTTP.Port != 8080 {
+			t.Errorf("port = %d, want the default 8080", cfg.HTTP.Port)
+		}
+	})
+
+	t.Run("prod origins", func(t *testing.T) {
+		t.Setenv("ENV", "prod")
+		t.Setenv("CONFIG_FILE", "")
+		t.Setenv("DATABASE_URL", "postgres://localhost/tasks")
+
+		cfg, err := Load("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"https://task-management-frontend.vercel.app"}; !reflect.DeepEqual(cfg.CORS.AllowedOrigins, want) {
+			t.Errorf("origins = %v, want %v", cfg.CORS.AllowedOrigins, want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			name string
+			env  map[string]string
+			want string
+		}{
+			{"missing database", map[string]string{"DATABASE_URL": ""}, "database.url"},
+			{"port out of range", map[string]string{"PORT": "70000"}, "http.port"},
+			{"unknown log level", map[string]string{"LOG_LEVEL": "verbose"}, "log.level"},
+			{"idle above open", map[string]string{"DB_MAX_IDLE_CONNS": "200"}, "database.max_idle_conns"},
+			{"unknown query level", map[string]string{"LOG_QUERY_LEVEL": "info"}, "log.query_level"},
+			{"unknown exporter", map[string]string{"TRACING_EXPORTER": "jaeger"}, "tracing.exporter"},
+			{"sample ratio above one", map[string]string{"TRACING_SAMPLE_RATIO": "1.5"}, "tracing.sample_ratio"},
+			{"unknown rate limit store", map[string]string{"RATE_LIMIT_STORE": "redis"}, "rate_limit.store"},
+			{"malformed duration", map[string]string{"HTTP_IDLE_TIMEOUT": "soon"}, "HTTP_IDLE_TIMEOUT"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Setenv("ENV", "test")
+				t.Setenv("CONFIG_FILE", "")
+				t.Setenv("DATABASE_URL", "postgres://localhost/tasks")
+				for name, value := range tt.env {
+					t.Setenv(name, value)
+				}
+
+				_, err := Load("")
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("Load() error = %v, want it to mention %s", err, tt.want)
+				}
+			})
+		}
+	})
+}
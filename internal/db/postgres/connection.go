@@ -1,47 +1,64 @@
 package postgres
 
 import (
-	"os"
-	"time"
+	"context"
+	"fmt"
 
-	"task-management/internal/entities"
+	"task-management/internal/config"
+	"task-management/internal/logging"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-func Connect() *gorm.DB {
-	connectionString := os.Getenv("DATABASE_URL")
-	if connectionString == "" {
-		panic("DATABASE_URL environment variable is not set")
+// Connect opens the configured database, applying the pending migrations
+// when cfg.Migrate is set, and panics on failure
+func Connect(cfg config.Database, log config.Log) *gorm.DB {
+	db, err := Open(cfg, log)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	if cfg.Migrate {
+		if _, err := Migrate(db); err != nil {
+			panic(err.Error())
+		}
 	}
 
-	db, err := gorm.Open(postgres.Open(connectionString), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	return db
+}
+
+// Open connects to the configured database without migrating it. Queries
+// are logged as set by log.
+func Open(cfg config.Database, log config.Log) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(cfg.URL), &gorm.Config{
+		Logger: logging.NewGormLogger(log),
 	})
 	if err != nil {
-		panic("failed to connect database: " + err.Error())
+		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		panic("failed to get database instance: " + err.Error())
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
 	}
 
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	if err := sqlDB.Ping(); err != nil {
-		panic("failed to ping database: " + err.Error())
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	if err := db.AutoMigrate(
-		&entities.Task{},
-	); err != nil {
-		panic("failed to auto-migrate database: " + err.Error())
-	}
+	return db, nil
+}
 
-	return db
+// Ping checks that the database answers within ctx
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
 }
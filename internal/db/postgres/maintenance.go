@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"task-management/internal/entities"
+
+	"gorm.io/gorm"
+)
+
+// PurgeDeletedTasks permanently removes the tasks deleted before the given
+// time, along with their label links. With dryRun it only counts them.
+func PurgeDeletedTasks(ctx context.Context, db *gorm.DB, before time.Time, dryRun bool) (int64, error) {
+	// Deleted tasks are hidden from the model's queries unless unscoped
+	deleted := func(tx *gorm.DB) *gorm.DB {
+		return tx.Unscoped().Model(&entities.Task{}).Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+	}
+
+	if dryRun {
+		var count int64
+		err := deleted(db.WithContext(ctx)).Count(&count).Error
+		return count, err
+	}
+
+	var purged int64
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_labels WHERE task_id IN (?)", deleted(tx).Select("id")).Error; err != nil {
+			return err
+		}
+
+		result := deleted(tx).Delete(&entities.Task{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+
+	return purged, err
+}
+
+// ReindexSearch rebuilds the full-text search index without blocking writes
+// and refreshes the planner statistics of the tasks table
+func ReindexSearch(ctx context.Context, db *gorm.DB) error {
+	// REINDEX CONCURRENTLY cannot run inside a transaction block, which the
+	// plain Exec below does not open
+	if err := db.WithContext(ctx).Exec("REINDEX INDEX CONCURRENTLY idx_tasks_search_vector").Error; err != nil {
+		return err
+	}
+	return db.WithContext(ctx).Exec("ANALYZE tasks").Error
+}
+
+// outboxStaleAfter is the age from which unrelayed outbox events are reported
+const outboxStaleAfter = 5 * time.Minute
+
+// CheckResult is the outcome of one health check
+type CheckResult struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+// Check verifies that the database is reachable and migrated and reports
+// the backlog of the background workers
+func Check(ctx context.Context, db *gorm.DB) []CheckResult {
+	var results []CheckResult
+	add := func(name string, ok bool, detail string, args ...interface{}) {
+		results = append(results, CheckResult{Name: name, OK: ok, Detail: fmt.Sprintf(detail, args...)})
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		add("connection", false, "%v", err)
+		return results
+	}
+	start := time.Now()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		add("connection", false, "%v", err)
+		return results
+	}
+	add("connection", true, "ping took %s", time.Since(start).Round(time.Microsecond))
+
+	var version string
+	if err := db.WithContext(ctx).Raw("SHOW server_version").Scan(&version).Error; err != nil {
+		add("version", false, "%v", err)
+	} else {
+		add("version", true, "PostgreSQL %s", version)
+	}
+
+	applied, pending, err := migrationCounts(ctx, db)
+	if err != nil {
+		add("migrations", false, "%v", err)
+		return results
+	}
+	if pending > 0 {
+		add("migrations", false, "%d applied, %d pending, run migrations", applied, pending)
+		return results
+	}
+	add("migrations", true, "%d applied", applied)
+
+	var failed int64
+	if err := db.WithContext(ctx).Model(&entities.WebhookDelivery{}).Where("status = ?", entities.DeliveryFailed).Count(&failed).Error; err != nil {
+		add("webhooks", false, "%v", err)
+	} else {
+		// Failed deliveries are a property of the receivers, not of the database
+		add("webhooks", true, "%d failed deliveries", failed)
+	}
+
+	// Events nobody consumed for a while point at a stopped relay
+	var stale int64
+	err = db.WithContext(ctx).Model(&entities.OutboxEvent{}).
+		Where("created_at < ?", time.Now().Add(-outboxStaleAfter)).
+		Where("NOT EXISTS (SELECT 1 FROM outbox_deliveries d WHERE d.event_id = outbox_events.id)").
+		Count(&stale).Error
+	switch {
+	case err != nil:
+		add("outbox", false, "%v", err)
+	case stale > 0:
+		add("outbox", false, "%d events older than %s were never relayed, is the relay running?", stale, outboxStaleAfter)
+	default:
+		add("outbox", true, "no stale events")
+	}
+
+	return results
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func NewMock() (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening gorm stub database connection", err)
+	}
+
+	return gormDB, mock
+}
+
+func TestPurgeDeletedTasks(t *testing.T) {
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("dry run counts", func(t *testing.T) {
+		gormDB, mock := NewMock()
+
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "tasks" WHERE deleted_at IS NOT NULL AND deleted_at < \$1`).
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := PurgeDeletedTasks(context.Background(), gormDB, before, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 3 {
+			t.Errorf("count = %d, want 3", count)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("removes labels then tasks", func(t *testing.T) {
+		gormDB, mock := NewMock()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM task_labels WHERE task_id IN \(SELECT "id" FROM "tasks" WHERE deleted_at IS NOT NULL AND deleted_at < \$1\)`).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec(`DELETE FROM "tasks" WHERE deleted_at IS NOT NULL AND deleted_at < \$1`).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		count, err := PurgeDeletedTasks(context.Background(), gormDB, before, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 2 {
+			t.Errorf("count = %d, want 2", count)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
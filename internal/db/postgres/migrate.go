@@ -0,0 +1,311 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationFiles holds the schema migrations. Each version has a
+// <version>_<name>.up.sql file applying it and a matching .down.sql file
+// reverting it. Applied migrations must never be edited; change the schema
+// with a new version instead.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the key of the advisory lock held while migrating, so
+// replicas starting together apply each migration once
+const migrationLockID int64 = 0x7461736b6d677274
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState is a migration along with when it was applied, nil while it
+// is pending
+type MigrationState struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// schemaMigration is a row of schema_migrations, which records the applied
+// migrations
+type schemaMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name text NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now()
+)`
+
+// Migrations returns the embedded migrations ordered by version
+func Migrations() ([]Migration, error) {
+	return loadMigrations(migrationFiles, "migrations")
+}
+
+// loadMigrations reads the migrations in dir of fsys, making sure every
+// version has both directions
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version %q: %w", entry.Name(), err)
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, match[2])
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrate applies the pending migrations in version order and returns them.
+// Each migration runs in its own transaction.
+func Migrate(db *gorm.DB) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	return migrate(db, migrations)
+}
+
+func migrate(db *gorm.DB, migrations []Migration) ([]Migration, error) {
+	var applied []Migration
+	err := withMigrationLock(db, func(conn *gorm.DB) error {
+		done, err := appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			if _, ok := done[m.Version]; ok {
+				continue
+			}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				// Without arguments the statements are sent in one simple
+				// query, so a file may hold several of them
+				if err := tx.Exec(m.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			applied = append(applied, m)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Rollback reverts the latest steps applied migrations, newest first, and
+// returns them. Reverting a migration may drop tables along with their data.
+func Rollback(db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	return rollback(db, migrations, steps)
+}
+
+func rollback(db *gorm.DB, migrations []Migration, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("cannot roll back %d migrations", steps)
+	}
+
+	known := make(map[int64]Migration, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = m
+	}
+
+	var reverted []Migration
+	err := withMigrationLock(db, func(conn *gorm.DB) error {
+		var done []schemaMigration
+		if err := conn.Order("version DESC").Limit(steps).Find(&done).Error; err != nil {
+			return err
+		}
+
+		for _, row := range done {
+			m, ok := known[row.Version]
+			if !ok {
+				return fmt.Errorf("migration %d_%s was applied by a newer build and cannot be reverted by this one", row.Version, row.Name)
+			}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(m.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{Version: m.Version}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			reverted = append(reverted, m)
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// MigrationStatus lists the embedded migrations and those the database
+// recorded, in version order
+func MigrationStatus(db *gorm.DB) ([]MigrationState, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []schemaMigration
+	if db.Migrator().HasTable(&schemaMigration{}) {
+		if err := db.Order("version").Find(&done).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	states := make(map[int64]MigrationState, len(migrations))
+	for _, m := range migrations {
+		states[m.Version] = MigrationState{Version: m.Version, Name: m.Name}
+	}
+	for _, row := range done {
+		appliedAt := row.AppliedAt
+		states[row.Version] = MigrationState{Version: row.Version, Name: row.Name, AppliedAt: &appliedAt}
+	}
+
+	result := make([]MigrationState, 0, len(states))
+	for _, state := range states {
+		result = append(result, state)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result, nil
+}
+
+// CheckMigrated returns an error while migrations are pending
+func CheckMigrated(ctx context.Context, db *gorm.DB) error {
+	_, pending, err := migrationCounts(ctx, db)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d migrations pending", pending)
+	}
+	return nil
+}
+
+// migrationCounts returns the number of applied and pending migrations
+func migrationCounts(ctx context.Context, db *gorm.DB) (applied, pending int, err error) {
+	states, err := MigrationStatus(db.WithContext(ctx))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, state := range states {
+		if state.AppliedAt != nil {
+			applied++
+		} else {
+			pending++
+		}
+	}
+	return applied, pending, nil
+}
+
+// withMigrationLock runs fn on a single connection holding the migration
+// lock, once schema_migrations exists. Session level advisory locks belong to
+// a connection, hence fn must not use db.
+func withMigrationLock(db *gorm.DB, fn func(conn *gorm.DB) error) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		// A new session keeps the pinned connection without sharing clauses
+		// between the statements run on it
+		conn = conn.Session(&gorm.Session{NewDB: true})
+
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID)
+
+		if err := conn.Exec(createMigrationsTable).Error; err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+		return fn(conn)
+	})
+}
+
+// appliedMigrations returns when each recorded migration was applied
+func appliedMigrations(db *gorm.DB) (map[int64]time.Time, error) {
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
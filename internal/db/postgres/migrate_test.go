@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Errorf("migration %d_%s: version should be %d", m.Version, m.Name, i+1)
+		}
+	}
+}
+
+var (
+	createTableRE = regexp.MustCompile(`(?is)^CREATE (?:UNLOGGED )?TABLE IF NOT EXISTS (\w+) \((.*)\)$`)
+	addColumnRE   = regexp.MustCompile(`(?is)^ALTER TABLE (\w+) ADD COLUMN IF NOT EXISTS (\w+)`)
+	createIndexRE = regexp.MustCompile(`(?is)^CREATE (?:UNIQUE )?INDEX IF NOT EXISTS \w+ ON (\w+)(?: USING \w+)? \((.*)\)$`)
+)
+
+// TestMigrations_baselineTasks walks the embedded migrations from the tasks
+// table AutoMigrate created, where CREATE TABLE IF NOT EXISTS is a no-op, and
+// checks every indexed column exists by the time its index is created.
+func TestMigrations_baselineTasks(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables := map[string]map[string]bool{
+		"tasks": {
+			"id": true, "created_at": true, "updated_at": true, "deleted_at": true,
+			"title": true, "description": true, "status": true, "due_date": true,
+		},
+	}
+	for _, m := range migrations {
+		for _, stmt := range strings.Split(m.Up, ";") {
+			var lines []string
+			for _, line := range strings.Split(stmt, "\n") {
+				if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+					lines = append(lines, line)
+				}
+			}
+			stmt = strings.Join(lines, "\n")
+			if match := createTableRE.FindStringSubmatch(stmt); match != nil {
+				if _, ok := tables[match[1]]; ok {
+					continue
+				}
+				columns := map[string]bool{}
+				for _, line := range strings.Split(match[2], "\n") {
+					if fields := strings.Fields(line); len(fields) > 0 {
+						columns[fields[0]] = true
+					}
+				}
+				tables[match[1]] = columns
+			} else if match := addColumnRE.FindStringSubmatch(stmt); match != nil {
+				if tables[match[1]] == nil {
+					t.Errorf("migration %d_%s: %s altered before it is created", m.Version, m.Name, match[1])
+					continue
+				}
+				tables[match[1]][match[2]] = true
+			} else if match := createIndexRE.FindStringSubmatch(stmt); match != nil {
+				for _, column := range strings.Split(match[2], ",") {
+					column = strings.Fields(column)[0]
+					if !tables[match[1]][column] {
+						t.Errorf("migration %d_%s: index on missing column %s.%s", m.Version, m.Name, match[1], column)
+					}
+				}
+			}
+		}
+	}
+}
+
+func Test_loadMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"m/0010_later.up.sql":   {Data: []byte("up 10")},
+				"m/0010_later.down.sql": {Data: []byte("down 10")},
+				"m/0002_first.up.sql":   {Data: []byte("up 2")},
+				"m/0002_first.down.sql": {Data: []byte("down 2")},
+			},
+			want: []string{"first", "later"},
+		},
+		{
+			name: "missing down",
+			files: fstest.MapFS{
+				"m/0001_tasks.up.sql": {Data: []byte("up")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflicting names",
+			files: fstest.MapFS{
+				"m/0001_tasks.up.sql":    {Data: []byte("up")},
+				"m/0001_labels.down.sql": {Data: []byte("down")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid name",
+			files: fstest.MapFS{
+				"m/tasks.sql": {Data: []byte("up")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadMigrations(tt.files, "m")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadMigrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("loadMigrations() = %v, want %v", got, tt.want)
+			}
+			for i, m := range got {
+				if m.Name != tt.want[i] {
+					t.Errorf("migration %d = %s, want %s", i, m.Name, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "tasks", Up: "CREATE TABLE tasks (id text)", Down: "DROP TABLE tasks"},
+	{Version: 2, Name: "labels", Up: "CREATE TABLE labels (id text)", Down: "DROP TABLE labels"},
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).
+		WithArgs(migrationLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).
+		WithArgs(migrationLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func Test_migrate(t *testing.T) {
+	gormDB, mock := NewMock()
+
+	expectLock(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).AddRow(1, "tasks", testTime))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE labels (id text)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "schema_migrations"`)).
+		WithArgs(int64(2), "labels", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	applied, err := migrate(gormDB, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0].Version != 2 {
+		t.Errorf("applied = %v, want only version 2", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_rollback(t *testing.T) {
+	t.Run("reverts newest first", func(t *testing.T) {
+		gormDB, mock := NewMock()
+
+		expectLock(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations" ORDER BY version DESC LIMIT $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).AddRow(2, "labels", testTime))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DROP TABLE labels")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "schema_migrations" WHERE "schema_migrations"."version" = $1`)).
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectUnlock(mock)
+
+		reverted, err := rollback(gormDB, testMigrations, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reverted) != 1 || reverted[0].Version != 2 {
+			t.Errorf("reverted = %v, want only version 2", reverted)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("unknown migration", func(t *testing.T) {
+		gormDB, mock := NewMock()
+
+		expectLock(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).AddRow(3, "views", testTime))
+		expectUnlock(mock)
+
+		if _, err := rollback(gormDB, testMigrations, 1); err == nil {
+			t.Error("expected an error reverting a migration this build does not know")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+-- The tables as AutoMigrate created them, so databases migrated before
+-- versioned migrations existed pass through this migration unchanged.
+-- Columns added to a table after the first release are also added
+-- explicitly, since CREATE TABLE IF NOT EXISTS leaves existing tables as
+-- they are.
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id text PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    title text NOT NULL,
+    description text,
+    status text NOT NULL DEFAULT 'Pending',
+    due_date timestamptz,
+    project_id text
+);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id text;
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS labels (
+    id text PRIMARY KEY,
+    created_at timestamptz,
+    name text NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_name ON labels (name);
+
+CREATE TABLE IF NOT EXISTS task_labels (
+    task_id text,
+    label_id text,
+    PRIMARY KEY (task_id, label_id),
+    CONSTRAINT fk_task_labels_task FOREIGN KEY (task_id) REFERENCES tasks (id),
+    CONSTRAINT fk_task_labels_label FOREIGN KEY (label_id) REFERENCES labels (id)
+);
+
+CREATE TABLE IF NOT EXISTS views (
+    id text PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    user_id text NOT NULL,
+    project_id text,
+    shared boolean NOT NULL DEFAULT false,
+    name text NOT NULL,
+    filter text,
+    status text,
+    sort text,
+    page_size bigint
+);
+CREATE INDEX IF NOT EXISTS idx_views_user_id ON views (user_id);
+CREATE INDEX IF NOT EXISTS idx_views_project_id ON views (project_id);
+
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    id text PRIMARY KEY,
+    created_at timestamptz,
+    user_id text NOT NULL,
+    name text,
+    token_hash text NOT NULL,
+    last_used_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_calendar_tokens_user_id ON calendar_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_tokens_token_hash ON calendar_tokens (token_hash);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id text PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    user_id text NOT NULL,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text,
+    active boolean NOT NULL DEFAULT true
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_user_id ON webhook_subscriptions (user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id text PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    subscription_id text NOT NULL,
+    event_id text NOT NULL,
+    event_type text NOT NULL,
+    payload text NOT NULL,
+    status text NOT NULL,
+    attempts bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz,
+    response_status bigint,
+    error text,
+    delivered_at timestamptz,
+    CONSTRAINT fk_webhook_deliveries_subscription FOREIGN KEY (subscription_id)
+        REFERENCES webhook_subscriptions (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries (created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (subscription_id, event_id);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id bigserial PRIMARY KEY,
+    event_id text NOT NULL,
+    created_at timestamptz NOT NULL,
+    type text NOT NULL,
+    task_id text NOT NULL,
+    project_id text,
+    payload text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_created_at ON outbox_events (created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_event_id ON outbox_events (event_id);
+
+CREATE TABLE IF NOT EXISTS outbox_deliveries (
+    consumer text,
+    event_id bigint,
+    delivered_at timestamptz,
+    PRIMARY KEY (consumer, event_id),
+    CONSTRAINT fk_outbox_deliveries_event FOREIGN KEY (event_id)
+        REFERENCES outbox_events (id) ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tasks_title_id;
+DROP INDEX IF EXISTS idx_tasks_status_id;
+DROP INDEX IF EXISTS idx_tasks_created_at_id;
+DROP INDEX IF EXISTS idx_tasks_updated_at_id;
+DROP INDEX IF EXISTS idx_tasks_due_date_asc_id;
+DROP INDEX IF EXISTS idx_tasks_due_date_desc_id;
@@ -0,0 +1,10 @@
+-- Back the whitelisted task sort keys, each paired with id which is used as
+-- the tiebreaker. due_date needs both directions because its nulls are kept
+-- last regardless of the requested order.
+
+CREATE INDEX IF NOT EXISTS idx_tasks_title_id ON tasks (title, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_status_id ON tasks (status, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_updated_at_id ON tasks (updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date_asc_id ON tasks (due_date ASC NULLS LAST, id ASC);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date_desc_id ON tasks (due_date DESC NULLS LAST, id DESC);
@@ -0,0 +1,11 @@
+-- Token buckets of the shared rate limiter. They are cheap to lose, a crash
+-- only hands every client a full bucket, so the table skips the WAL.
+
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key text PRIMARY KEY,
+    tokens double precision NOT NULL,
+    rate double precision NOT NULL,
+    burst integer NOT NULL,
+    allowed boolean NOT NULL,
+    updated_at timestamptz NOT NULL
+);
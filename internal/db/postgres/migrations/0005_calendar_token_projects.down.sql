@@ -0,0 +1 @@
+ALTER TABLE calendar_tokens DROP COLUMN IF EXISTS project_id;
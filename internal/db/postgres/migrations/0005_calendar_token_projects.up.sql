@@ -0,0 +1,4 @@
+-- Calendar feeds are limited to the project of the user who created the
+-- token. Existing tokens have none and only publish tasks without a project.
+
+ALTER TABLE calendar_tokens ADD COLUMN IF NOT EXISTS project_id text;
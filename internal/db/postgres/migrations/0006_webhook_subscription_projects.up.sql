@@ -0,0 +1,5 @@
+-- Webhooks only receive the events of tasks visible from the project of the
+-- user who subscribed. Existing subscriptions have none and only receive the
+-- events of tasks without a project.
+
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS project_id text;
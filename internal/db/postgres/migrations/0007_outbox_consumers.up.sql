@@ -0,0 +1,14 @@
+-- Consumers of the outbox, so events are pruned once every consumer got them
+-- whatever publishers the running relays have. Relays claim a consumer while
+-- publishing to it instead of holding a transaction open.
+
+CREATE TABLE IF NOT EXISTS outbox_consumers (
+    name text PRIMARY KEY,
+    last_seen_at timestamptz NOT NULL,
+    claim text,
+    claimed_until timestamptz
+);
+
+INSERT INTO outbox_consumers (name, last_seen_at)
+SELECT DISTINCT consumer, now() FROM outbox_deliveries
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,79 @@
+package entities
+
+import "github.com/gofrs/uuid"
+
+// MaxBulkItems caps the number of operations in a single bulk request
+const MaxBulkItems = 100
+
+type BulkOperation string
+
+const (
+	BulkCreate       BulkOperation = "create"
+	BulkSetStatus    BulkOperation = "set_status"
+	BulkDelete       BulkOperation = "delete"
+	BulkAddLabels    BulkOperation = "add_labels"
+	BulkRemoveLabels BulkOperation = "remove_labels"
+)
+
+type BulkItemStatus string
+
+const (
+	BulkSucceeded  BulkItemStatus = "succeeded"
+	BulkFailed     BulkItemStatus = "failed"
+	BulkRolledBack BulkItemStatus = "rolled_back"
+	BulkSkipped    BulkItemStatus = "skipped"
+)
+
+// BulkItem is a single operation of a bulk request. create uses Task,
+// set_status uses ID and Status, delete uses ID and the label operations
+// use ID and Labels.
+type BulkItem struct {
+	Op     BulkOperation `json:"op" validate:"required,oneof=create set_status delete add_labels remove_labels"`
+	ID     *uuid.UUID    `json:"id"`
+	Task   *TaskRequest  `json:"task"`
+	Status TaskStatus    `json:"status"`
+	Labels []string      `json:"labels" validate:"max=20,dive,required,max=50"`
+}
+
+// BulkRequest groups task operations. Atomic requests, the default, run in
+// a single transaction and stop at the first failure; with atomic set to
+// false every item is applied on its own.
+type BulkRequest struct {
+	Atomic *bool      `json:"atomic"`
+	Items  []BulkItem `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// IsAtomic reports whether the request runs in a single transaction
+func (r *BulkRequest) IsAtomic() bool {
+	return r.Atomic == nil || *r.Atomic
+}
+
+type BulkItemResult struct {
+	Index  int            `json:"index"`
+	Op     BulkOperation  `json:"op"`
+	ID     *uuid.UUID     `json:"id,omitempty"`
+	Status BulkItemStatus `json:"status"`
+	Error  string         `json:"error,omitempty"`
+}
+
+type BulkResponse struct {
+	Atomic    bool             `json:"atomic"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// Record stores the outcome of the item at index i
+func (r *BulkResponse) Record(i int, id *uuid.UUID, err error) {
+	if id != nil {
+		r.Results[i].ID = id
+	}
+	if err != nil {
+		r.Results[i].Status = BulkFailed
+		r.Results[i].Error = err.Error()
+		r.Failed++
+		return
+	}
+	r.Results[i].Status = BulkSucceeded
+	r.Succeeded++
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// CalendarToken grants read access to a user's task calendar feed. Calendar
+// clients cannot send gateway headers, so the feed URL carries the token
+// instead. Only its SHA-256 hash is stored. The feed shows what the user
+// could see when creating the token: the tasks of ProjectID and those
+// without a project.
+type CalendarToken struct {
+	ID         uuid.UUID  `json:"id" gorm:"primaryKey"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"not null;index"`
+	ProjectID  *uuid.UUID `json:"project_id"`
+	Name       string     `json:"name"`
+	TokenHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+type CalendarTokenRequest struct {
+	Name string `json:"name" validate:"max=100"`
+}
+
+type CalendarTokenResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	ProjectID  *uuid.UUID `json:"project_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	// Token is only returned when the token is created
+	Token string `json:"token,omitempty"`
+}
+
+// CalendarFeedOptions selects the tasks published in a calendar feed
+type CalendarFeedOptions struct {
+	Token    string
+	Statuses []TaskStatus
+}
@@ -0,0 +1,10 @@
+package entities
+
+import "time"
+
+// CollabTicketResponse carries a ticket for opening the collaboration
+// WebSocket from a browser, which cannot send the gateway headers
+type CollabTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
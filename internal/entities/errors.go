@@ -3,5 +3,63 @@ package entities
 import "errors"
 
 var (
-	ErrTaskNotFound = errors.New("task not found")
+	ErrTaskNotFound  = errors.New("task not found")
+	ErrInvalidSort   = errors.New("invalid sort")
+	ErrEmptyQuery    = errors.New("search query must contain at least one word")
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrInvalidFields = errors.New("invalid fields")
+	ErrViewNotFound  = errors.New("view not found")
+	ErrInvalidBulk   = errors.New("invalid bulk item")
+	ErrInvalidView   = errors.New("invalid view")
+	ErrUnauthorized  = errors.New("missing or invalid X-User-ID header")
+	ErrForbidden     = errors.New("not allowed to modify this resource")
+
+	ErrCalendarTokenNotFound = errors.New("calendar token not found")
+	ErrInvalidCalendarToken  = errors.New("missing or invalid calendar token")
+	ErrWebhookNotFound       = errors.New("webhook not found")
+	ErrInvalidWebhookURL     = errors.New("invalid webhook URL")
+)
+
+// ErrorKind classifies errors for clients. Each API reports it its own way,
+// as an HTTP status, a gRPC code or a GraphQL error code.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindInvalid
+	KindUnauthenticated
+	KindForbidden
+	KindNotFound
 )
+
+// errorKinds lists the errors clients are told about, any other error is
+// internal
+var errorKinds = []struct {
+	err  error
+	kind ErrorKind
+}{
+	{ErrInvalidSort, KindInvalid},
+	{ErrInvalidFilter, KindInvalid},
+	{ErrInvalidFields, KindInvalid},
+	{ErrInvalidView, KindInvalid},
+	{ErrInvalidBulk, KindInvalid},
+	{ErrInvalidWebhookURL, KindInvalid},
+	{ErrEmptyQuery, KindInvalid},
+	{ErrUnauthorized, KindUnauthenticated},
+	{ErrInvalidCalendarToken, KindUnauthenticated},
+	{ErrForbidden, KindForbidden},
+	{ErrTaskNotFound, KindNotFound},
+	{ErrViewNotFound, KindNotFound},
+	{ErrCalendarTokenNotFound, KindNotFound},
+	{ErrWebhookNotFound, KindNotFound},
+}
+
+// KindOf returns the kind of err, or of the error it wraps
+func KindOf(err error) ErrorKind {
+	for _, e := range errorKinds {
+		if errors.Is(err, e.err) {
+			return e.kind
+		}
+	}
+	return KindInternal
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// TaskEventType names a change made to a task
+type TaskEventType string
+
+const (
+	EventTaskCreated       TaskEventType = "task.created"
+	EventTaskUpdated       TaskEventType = "task.updated"
+	EventTaskDeleted       TaskEventType = "task.deleted"
+	EventTaskStatusChanged TaskEventType = "task.status_changed"
+)
+
+// TaskEventTypes lists every event type, in the order they are documented
+var TaskEventTypes = []TaskEventType{
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskDeleted,
+	EventTaskStatusChanged,
+}
+
+// TaskEvent describes a change made to a task. Task holds the task after the
+// change and is omitted for deletions; ProjectID is set for every event so
+// deletions can be routed too. A task moving to another project is updated
+// in both: the previous project gets an update without Task, telling its
+// members the task left.
+type TaskEvent struct {
+	ID             uuid.UUID     `json:"id"`
+	Type           TaskEventType `json:"type"`
+	OccurredAt     time.Time     `json:"occurred_at"`
+	TaskID         uuid.UUID     `json:"task_id"`
+	ProjectID      *uuid.UUID    `json:"project_id,omitempty"`
+	Task           *TaskResponse `json:"task,omitempty"`
+	PreviousStatus *TaskStatus   `json:"previous_status,omitempty"`
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskFields is the whitelist of fields that can be selected in task responses
+var TaskFields = map[string]bool{
+	"id":          true,
+	"title":       true,
+	"description": true,
+	"status":      true,
+	"due_date":    true,
+	"project_id":  true,
+	"created_at":  true,
+	"updated_at":  true,
+	"labels":      true,
+}
+
+// TaskIncludes lists the related data embedded in task responses
+type TaskIncludes struct {
+	Labels bool
+}
+
+// ParseTaskFields parses a comma separated list of response fields. An empty
+// list selects every field.
+func ParseTaskFields(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if !TaskFields[field] {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFields, field)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// ParseTaskIncludes parses a comma separated list of relations to embed
+func ParseTaskIncludes(raw string) (TaskIncludes, error) {
+	var includes TaskIncludes
+	if strings.TrimSpace(raw) == "" {
+		return includes, nil
+	}
+
+	for _, include := range strings.Split(raw, ",") {
+		switch include = strings.TrimSpace(include); include {
+		case "labels":
+			includes.Labels = true
+		default:
+			return includes, fmt.Errorf("%w: unknown include %q", ErrInvalidFields, include)
+		}
+	}
+
+	return includes, nil
+}
@@ -0,0 +1,21 @@
+package entities
+
+// ImportRow is a task read from an import file along with the row it came
+// from, counted from 1 and excluding the CSV header
+type ImportRow struct {
+	Row  int
+	Task TaskRequest
+}
+
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Field string `json:"field,omitempty"`
+	Error string `json:"error"`
+}
+
+type ImportResult struct {
+	DryRun   bool             `json:"dry_run"`
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors,omitempty"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type Label struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex"`
+}
+
+// NormalizeLabel returns the canonical form of a label name. Labels are
+// case-insensitive so "Backend" and "backend" refer to the same label.
+func NormalizeLabel(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
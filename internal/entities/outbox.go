@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// OutboxEvent is a task event saved in the same transaction as the change
+// it describes, waiting to be published. ID orders events by creation.
+type OutboxEvent struct {
+	ID        int64         `gorm:"primaryKey;autoIncrement"`
+	EventID   uuid.UUID     `gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time     `gorm:"not null;index"`
+	Type      TaskEventType `gorm:"not null"`
+	TaskID    uuid.UUID     `gorm:"not null"`
+	ProjectID *uuid.UUID
+	// Payload is the JSON encoded TaskEvent
+	Payload string `gorm:"type:text;not null"`
+}
+
+// OutboxDelivery records that an outbox event was published to a consumer.
+// The primary key makes sure it is only recorded once per consumer.
+type OutboxDelivery struct {
+	Consumer    string `gorm:"primaryKey"`
+	EventID     int64  `gorm:"primaryKey"`
+	DeliveredAt time.Time
+
+	Event *OutboxEvent `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// OutboxConsumer is a publisher the relay publishes to. Events are kept until
+// every consumer seen within the retention period received them. A relay
+// publishing to the consumer holds a claim on it until ClaimedUntil.
+type OutboxConsumer struct {
+	Name         string `gorm:"primaryKey"`
+	LastSeenAt   time.Time
+	Claim        *uuid.UUID
+	ClaimedUntil *time.Time
+}
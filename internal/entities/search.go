@@ -0,0 +1,30 @@
+package entities
+
+// TaskSearchOptions holds the full-text query and the filters applied to it
+type TaskSearchOptions struct {
+	Query    string
+	Page     int
+	PageSize int
+	Status   *TaskStatus
+	Filter   *TaskFilter
+}
+
+// TaskSearchHit is a task matched by a full-text search along with its rank
+// and the highlighted fragments produced by the database
+type TaskSearchHit struct {
+	Task
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+type TaskHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type TaskSearchResponse struct {
+	TaskResponse
+	Rank       float64        `json:"rank"`
+	Highlights TaskHighlights `json:"highlights"`
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSortKeys caps how many keys a single sort expression may carry
+const maxSortKeys = 5
+
+// TaskSortColumns maps the public sort keys to their database columns
+var TaskSortColumns = map[string]string{
+	"title":      "title",
+	"status":     "status",
+	"due_date":   "due_date",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// DefaultTaskSort is applied when the caller does not ask for an ordering
+var DefaultTaskSort = []TaskSort{{Field: "created_at", Desc: true}}
+
+// TaskSort is a single ordering key of a task listing
+type TaskSort struct {
+	Field string
+	Desc  bool
+}
+
+// ParseTaskSort parses a comma separated list of sort keys such as
+// "-due_date,title". A leading "-" sorts the key in descending order.
+func ParseTaskSort(raw string) ([]TaskSort, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	keys := strings.Split(raw, ",")
+	if len(keys) > maxSortKeys {
+		return nil, fmt.Errorf("%w: at most %d keys are allowed", ErrInvalidSort, maxSortKeys)
+	}
+
+	seen := make(map[string]bool, len(keys))
+	sorts := make([]TaskSort, 0, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+
+		desc := strings.HasPrefix(key, "-")
+		field := strings.TrimPrefix(key, "-")
+
+		if _, ok := TaskSortColumns[field]; !ok {
+			return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidSort, key)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("%w: duplicate key %q", ErrInvalidSort, field)
+		}
+		seen[field] = true
+
+		sorts = append(sorts, TaskSort{Field: field, Desc: desc})
+	}
+
+	return sorts, nil
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
 )
 
 // Task status enum
@@ -16,15 +17,28 @@ const (
 	StatusCancelled  TaskStatus = "Cancelled"
 )
 
+// Valid reports whether the status is one of the known task statuses
+func (s TaskStatus) Valid() bool {
+	switch s {
+	case StatusPending, StatusInProgress, StatusCompleted, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Task is soft deleted: deleting it sets DeletedAt, which hides it from
+// every query, until admin purge removes it for good
 type Task struct {
-	ID          uuid.UUID  `json:"id" gorm:"primaryKey"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at" gorm:"index"`
-	Title       string     `json:"title" gorm:"not null" validate:"required"`
-	Description string     `json:"description" gorm:"type:text"`
-	Status      TaskStatus `json:"status" gorm:"not null;default:'Pending'" validate:"required"`
-	DueDate     *time.Time `json:"due_date"`
+	ID          uuid.UUID      `json:"id" gorm:"primaryKey"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	Title       string         `json:"title" gorm:"not null" validate:"required"`
+	Description string         `json:"description" gorm:"type:text"`
+	Status      TaskStatus     `json:"status" gorm:"not null;default:'Pending'" validate:"required"`
+	DueDate     *time.Time     `json:"due_date"`
+	ProjectID   *uuid.UUID     `json:"project_id" gorm:"index"`
+	Labels      []Label        `json:"labels,omitempty" gorm:"many2many:task_labels"`
 }
 
 type TaskRequest struct {
@@ -32,6 +46,8 @@ type TaskRequest struct {
 	Description string     `json:"description"`
 	Status      TaskStatus `json:"status" validate:"required"`
 	DueDate     *time.Time `json:"due_date"`
+	ProjectID   *uuid.UUID `json:"project_id"`
+	Labels      []string   `json:"labels" validate:"max=20,dive,required,max=50"`
 }
 
 type TaskResponse struct {
@@ -40,6 +56,28 @@ type TaskResponse struct {
 	Description string     `json:"description"`
 	Status      TaskStatus `json:"status"`
 	DueDate     *time.Time `json:"due_date"`
+	ProjectID   *uuid.UUID `json:"project_id"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+	Labels      []string   `json:"labels"` // null unless the labels are included
+}
+
+// MaxPageSize bounds the page size of task listings in every API
+const MaxPageSize = 100
+
+// TaskListOptions holds the pagination, filtering and ordering of a task listing
+type TaskListOptions struct {
+	Page     int
+	PageSize int
+	Status   *TaskStatus
+	Sort     []TaskSort
+	Filter   *TaskFilter
+	Include  TaskIncludes
+}
+
+// TaskFilter is a compiled filter expression, ready to be used as a where
+// clause. It is produced by the filter package.
+type TaskFilter struct {
+	Clause string
+	Args   []interface{}
 }
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// View is a named combination of task filters, ordering and page size saved
+// by a user. Shared views are visible to every member of their project.
+type View struct {
+	ID        uuid.UUID   `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	UserID    uuid.UUID   `json:"user_id" gorm:"not null;index"`
+	ProjectID *uuid.UUID  `json:"project_id" gorm:"index"`
+	Shared    bool        `json:"shared" gorm:"not null;default:false"`
+	Name      string      `json:"name" gorm:"not null"`
+	Filter    string      `json:"filter" gorm:"type:text"`
+	Status    *TaskStatus `json:"status"`
+	Sort      string      `json:"sort"`
+	PageSize  int         `json:"page_size"`
+}
+
+type ViewRequest struct {
+	Name      string      `json:"name" validate:"required,max=100"`
+	Filter    string      `json:"filter"`
+	Status    *TaskStatus `json:"status"`
+	Sort      string      `json:"sort"`
+	PageSize  int         `json:"page_size" validate:"min=0,max=100"`
+	Shared    bool        `json:"shared"`
+	ProjectID *uuid.UUID  `json:"project_id"`
+}
+
+type ViewResponse struct {
+	ID        uuid.UUID   `json:"id"`
+	UserID    uuid.UUID   `json:"user_id"`
+	ProjectID *uuid.UUID  `json:"project_id"`
+	Shared    bool        `json:"shared"`
+	Name      string      `json:"name"`
+	Filter    string      `json:"filter"`
+	Status    *TaskStatus `json:"status"`
+	Sort      string      `json:"sort"`
+	PageSize  int         `json:"page_size"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
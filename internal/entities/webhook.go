@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// WebhookSubscription asks for task events to be posted to a URL. Payloads
+// are signed with the subscription secret. Only the events of tasks visible
+// from ProjectID, the project of the user who subscribed, are delivered.
+type WebhookSubscription struct {
+	ID        uuid.UUID  `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"not null;index"`
+	ProjectID *uuid.UUID `json:"project_id"`
+	URL       string     `json:"url" gorm:"not null"`
+	Secret    string     `json:"-" gorm:"not null"`
+	// Events holds the comma separated event types to deliver, all when empty
+	Events string `json:"events"`
+	Active bool   `json:"active" gorm:"not null"`
+}
+
+// Wants reports whether the subscription asked for the event type
+func (s *WebhookSubscription) Wants(eventType TaskEventType) bool {
+	if s.Events == "" {
+		return true
+	}
+	for _, e := range strings.Split(s.Events, ",") {
+		if TaskEventType(e) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2048"`
+	Events []string `json:"events" validate:"dive,oneof=task.created task.updated task.deleted task.status_changed"`
+	Active *bool    `json:"active"`
+}
+
+type WebhookResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	ProjectID *uuid.UUID `json:"project_id"`
+	URL       string     `json:"url"`
+	Events    []string   `json:"events"`
+	Active    bool       `json:"active"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	// Secret is only returned when the subscription is created
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookDeliveryStatus is the state of a delivery
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent to one subscription, along with the
+// outcome of its latest attempt. An event is queued at most once per
+// subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID `gorm:"primaryKey"`
+	CreatedAt      time.Time `gorm:"index"`
+	UpdatedAt      time.Time
+	SubscriptionID uuid.UUID             `gorm:"not null;uniqueIndex:idx_webhook_deliveries_event,priority:1"`
+	EventID        uuid.UUID             `gorm:"not null;uniqueIndex:idx_webhook_deliveries_event,priority:2"`
+	EventType      TaskEventType         `gorm:"not null"`
+	Payload        string                `gorm:"type:text;not null"`
+	Status         WebhookDeliveryStatus `gorm:"not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int                   `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	ResponseStatus int
+	Error          string
+	DeliveredAt    *time.Time
+
+	Subscription *WebhookSubscription `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID             `json:"id"`
+	EventID        uuid.UUID             `json:"event_id"`
+	EventType      TaskEventType         `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload" swaggertype:"object"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	Error          string                `json:"error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// SplitEvents reads the event types stored in WebhookSubscription.Events
+func SplitEvents(events string) []string {
+	if events == "" {
+		return []string{}
+	}
+	return strings.Split(events, ",")
+}
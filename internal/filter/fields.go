@@ -0,0 +1,153 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"task-management/internal/entities"
+)
+
+// operators maps every operator accepted by the lexer to its SQL form.
+// ":" is the loose match: contains for text, same day for dates.
+var operators = map[string]string{
+	":":  ":",
+	"=":  "=",
+	"!=": "<>",
+	"<":  "<",
+	"<=": "<=",
+	">":  ">",
+	">=": ">=",
+}
+
+var (
+	equality = []string{":", "=", "<>"}
+	ordered  = []string{":", "=", "<>", "<", "<=", ">", ">="}
+)
+
+// field describes a filterable task attribute
+type field struct {
+	ops     []string
+	compile func(op, value string) (string, []interface{}, error)
+}
+
+func (f field) allows(op string) bool {
+	for _, o := range f.ops {
+		if o == operators[op] {
+			return true
+		}
+	}
+	return false
+}
+
+// fields is the whitelist of attributes an expression may reference
+var fields = map[string]field{
+	"status":      {ops: equality, compile: compileStatus},
+	"title":       {ops: equality, compile: compileText("tasks.title")},
+	"description": {ops: equality, compile: compileText("tasks.description")},
+	"due":         {ops: ordered, compile: compileTime("tasks.due_date", true)},
+	"created":     {ops: ordered, compile: compileTime("tasks.created_at", false)},
+	"updated":     {ops: ordered, compile: compileTime("tasks.updated_at", false)},
+	"label":       {ops: equality, compile: compileLabel},
+}
+
+func fieldNames() string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func compileStatus(op, value string) (string, []interface{}, error) {
+	for _, status := range []entities.TaskStatus{
+		entities.StatusPending,
+		entities.StatusInProgress,
+		entities.StatusCompleted,
+		entities.StatusCancelled,
+	} {
+		if strings.EqualFold(value, string(status)) {
+			if op == "<>" {
+				return "tasks.status <> ?", []interface{}{status}, nil
+			}
+			return "tasks.status = ?", []interface{}{status}, nil
+		}
+	}
+	return "", nil, errors.New("unknown status")
+}
+
+func compileText(column string) func(op, value string) (string, []interface{}, error) {
+	return func(op, value string) (string, []interface{}, error) {
+		if op == ":" {
+			return column + ` ILIKE ? ESCAPE '\'`, []interface{}{"%" + escapeLike(value) + "%"}, nil
+		}
+		return column + " " + op + " ?", []interface{}{value}, nil
+	}
+}
+
+// escapeLike escapes the LIKE wildcards so values are matched literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// compileTime compares a timestamp column against either a calendar day
+// (2006-01-02, compared as the whole UTC day) or an RFC 3339 instant.
+// Nullable columns also accept "none" to match rows without a value.
+func compileTime(column string, nullable bool) func(op, value string) (string, []interface{}, error) {
+	return func(op, value string) (string, []interface{}, error) {
+		if nullable && strings.EqualFold(value, "none") {
+			switch op {
+			case ":", "=":
+				return column + " IS NULL", nil, nil
+			case "<>":
+				return column + " IS NOT NULL", nil, nil
+			}
+			return "", nil, errors.New("none can only be compared for equality")
+		}
+
+		if instant, err := time.Parse(time.RFC3339, value); err == nil {
+			if op == ":" {
+				op = "="
+			}
+			return column + " " + op + " ?", []interface{}{instant}, nil
+		}
+
+		day, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", nil, errors.New("expected a date (2006-01-02) or an RFC 3339 timestamp")
+		}
+		next := day.AddDate(0, 0, 1)
+
+		switch op {
+		case ":", "=":
+			return fmt.Sprintf("(%s >= ? AND %s < ?)", column, column), []interface{}{day, next}, nil
+		case "<>":
+			return fmt.Sprintf("NOT (%s >= ? AND %s < ?)", column, column), []interface{}{day, next}, nil
+		case "<":
+			return column + " < ?", []interface{}{day}, nil
+		case "<=":
+			return column + " < ?", []interface{}{next}, nil
+		case ">":
+			return column + " >= ?", []interface{}{next}, nil
+		default:
+			return column + " >= ?", []interface{}{day}, nil
+		}
+	}
+}
+
+const labelExists = "EXISTS (SELECT 1 FROM task_labels JOIN labels ON labels.id = task_labels.label_id " +
+	"WHERE task_labels.task_id = tasks.id AND labels.name = ?)"
+
+func compileLabel(op, value string) (string, []interface{}, error) {
+	name := entities.NormalizeLabel(value)
+	if name == "" {
+		return "", nil, errors.New("label must not be empty")
+	}
+	if op == "<>" {
+		return "NOT " + labelExists, []interface{}{name}, nil
+	}
+	return labelExists, []interface{}{name}, nil
+}
@@ -0,0 +1,202 @@
+// Package filter implements the small query language used to filter task
+// listings, e.g.
+//
+//	status:InProgress AND due<2026-11-01 AND label:backend
+//
+// Terms have the form field<op>value and can be combined with AND, OR, NOT
+// and parentheses. Terms written next to each other are joined with AND.
+// Expressions are compiled into a parameterized SQL condition: field names
+// are resolved against a fixed whitelist and values are always passed as
+// arguments, never spliced into the SQL.
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"task-management/internal/entities"
+)
+
+const (
+	// maxLength bounds the size of an expression in characters
+	maxLength = 1024
+
+	// maxDepth bounds how deeply expressions may nest
+	maxDepth = 16
+)
+
+// Error describes why an expression was rejected and which token caused it
+type Error struct {
+	Pos   int
+	Token string
+	Msg   string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s at position %d near %q", entities.ErrInvalidFilter, e.Msg, e.Pos, e.Token)
+}
+
+// Unwrap lets callers match filter errors with errors.Is
+func (e *Error) Unwrap() error {
+	return entities.ErrInvalidFilter
+}
+
+// Parse compiles a filter expression into a task filter. An empty expression
+// yields a nil filter.
+func Parse(input string) (*entities.TaskFilter, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+	if len([]rune(input)) > maxLength {
+		return nil, &Error{Pos: maxLength + 1, Token: "", Msg: fmt.Sprintf("expression is longer than %d characters", maxLength)}
+	}
+
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	clause, args, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected token")
+	}
+
+	return &entities.TaskFilter{Clause: "(" + clause + ")", Args: args}, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, a ...interface{}) error {
+	return &Error{Pos: tok.pos, Token: tok.describe(), Msg: fmt.Sprintf(format, a...)}
+}
+
+// isKeyword reports whether the token is the given boolean keyword
+func isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokenWord && strings.EqualFold(tok.value, keyword)
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *parser) parseOr(depth int) (string, []interface{}, error) {
+	clause, args, err := p.parseAnd(depth)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for isKeyword(p.peek(), "OR") {
+		p.next()
+		right, rightArgs, err := p.parseAnd(depth)
+		if err != nil {
+			return "", nil, err
+		}
+		clause = clause + " OR " + right
+		args = append(args, rightArgs...)
+	}
+
+	return clause, args, nil
+}
+
+// parseAnd parses: unary (["AND"] unary)*
+func (p *parser) parseAnd(depth int) (string, []interface{}, error) {
+	clause, args, err := p.parseUnary(depth)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if isKeyword(tok, "AND") {
+			p.next()
+		} else if tok.kind == tokenEOF || tok.kind == tokenRParen || isKeyword(tok, "OR") {
+			return clause, args, nil
+		}
+
+		right, rightArgs, err := p.parseUnary(depth)
+		if err != nil {
+			return "", nil, err
+		}
+		clause = clause + " AND " + right
+		args = append(args, rightArgs...)
+	}
+}
+
+// parseUnary parses: "NOT" unary | "(" or ")" | term
+func (p *parser) parseUnary(depth int) (string, []interface{}, error) {
+	tok := p.peek()
+	if depth > maxDepth {
+		return "", nil, p.errorf(tok, "expression is nested more than %d levels deep", maxDepth)
+	}
+
+	switch {
+	case isKeyword(tok, "NOT"):
+		p.next()
+		clause, args, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + clause, args, nil
+
+	case tok.kind == tokenLParen:
+		p.next()
+		clause, args, err := p.parseOr(depth + 1)
+		if err != nil {
+			return "", nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return "", nil, p.errorf(closing, "expected \")\"")
+		}
+		return "(" + clause + ")", args, nil
+
+	case tok.kind == tokenWord && !isKeyword(tok, "AND") && !isKeyword(tok, "OR"):
+		return p.parseTerm()
+	}
+
+	return "", nil, p.errorf(tok, "expected a field name")
+}
+
+// parseTerm parses: field operator value
+func (p *parser) parseTerm() (string, []interface{}, error) {
+	name := p.next()
+	f, ok := fields[strings.ToLower(name.value)]
+	if !ok {
+		return "", nil, p.errorf(name, "unknown field, expected one of %s", fieldNames())
+	}
+
+	op := p.next()
+	if op.kind != tokenOperator {
+		return "", nil, p.errorf(op, "expected an operator after %q", name.value)
+	}
+	if !f.allows(op.value) {
+		return "", nil, p.errorf(op, "operator %s is not supported by field %q", op.value, name.value)
+	}
+
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return "", nil, p.errorf(value, "expected a value for %q", name.value)
+	}
+
+	clause, args, err := f.compile(operators[op.value], value.value)
+	if err != nil {
+		return "", nil, p.errorf(value, "%s", err)
+	}
+
+	return clause, args, nil
+}
@@ -0,0 +1,116 @@
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"task-management/internal/entities"
+)
+
+func TestParse(t *testing.T) {
+	day := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	nextDay := day.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name  string
+		input string
+		want  *entities.TaskFilter
+	}{
+		{
+			name:  "empty expression",
+			input: "   ",
+			want:  nil,
+		},
+		{
+			name:  "explicit and",
+			input: "status:InProgress AND due<2026-11-01 AND label:Backend",
+			want: &entities.TaskFilter{
+				Clause: "(tasks.status = ? AND tasks.due_date < ? AND " + labelExists + ")",
+				Args:   []interface{}{entities.StatusInProgress, day, "backend"},
+			},
+		},
+		{
+			name:  "implicit and with or, not and grouping",
+			input: `title:"login bug" (status=pending OR NOT status!=cancelled)`,
+			want: &entities.TaskFilter{
+				Clause: `(tasks.title ILIKE ? ESCAPE '\' AND (tasks.status = ? OR NOT tasks.status <> ?))`,
+				Args:   []interface{}{"%login bug%", entities.StatusPending, entities.StatusCancelled},
+			},
+		},
+		{
+			name:  "whole day and missing due date",
+			input: "created:2026-11-01 or due:none",
+			want: &entities.TaskFilter{
+				Clause: "((tasks.created_at >= ? AND tasks.created_at < ?) OR tasks.due_date IS NULL)",
+				Args:   []interface{}{day, nextDay},
+			},
+		},
+		{
+			name:  "unquoted timestamp",
+			input: "due<2026-11-01T10:00:00Z AND (updated>=2026-11-01T09:30:00+02:00)",
+			want: &entities.TaskFilter{
+				Clause: "(tasks.due_date < ? AND (tasks.updated_at >= ?))",
+				Args:   []interface{}{day.Add(10 * time.Hour), time.Date(2026, 11, 1, 9, 30, 0, 0, time.FixedZone("", 2*60*60))},
+			},
+		},
+		{
+			name:  "like wildcards are escaped",
+			input: "description:100%_done",
+			want: &entities.TaskFilter{
+				Clause: `(tasks.description ILIKE ? ESCAPE '\')`,
+				Args:   []interface{}{`%100\%\_done%`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantPos int
+		wantTok string
+	}{
+		{name: "unknown field", input: "status:Pending AND owner:me", wantPos: 20, wantTok: "owner"},
+		{name: "unknown status", input: "status:Done", wantPos: 8, wantTok: "Done"},
+		{name: "unsupported operator", input: "label<backend", wantPos: 6, wantTok: "<"},
+		{name: "unknown operator", input: "due=<2026-11-01", wantPos: 4, wantTok: "=<"},
+		{name: "bad date", input: "due<tomorrow", wantPos: 5, wantTok: "tomorrow"},
+		{name: "missing value", input: "status:", wantPos: 8, wantTok: "end of input"},
+		{name: "dangling or", input: "status:Pending OR", wantPos: 18, wantTok: "end of input"},
+		{name: "unclosed group", input: "(status:Pending", wantPos: 16, wantTok: "end of input"},
+		{name: "stray paren", input: "status:Pending)", wantPos: 15, wantTok: ")"},
+		{name: "unterminated string", input: `title:"login`, wantPos: 7, wantTok: `"login`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if !errors.Is(err, entities.ErrInvalidFilter) {
+				t.Fatalf("Parse() error = %v, want ErrInvalidFilter", err)
+			}
+
+			var ferr *Error
+			if !errors.As(err, &ferr) {
+				t.Fatalf("Parse() error = %T, want *Error", err)
+			}
+			if ferr.Pos != tt.wantPos || ferr.Token != tt.wantTok {
+				t.Errorf("Parse() error at %d near %q, want %d near %q (%v)", ferr.Pos, ferr.Token, tt.wantPos, tt.wantTok, err)
+			}
+		})
+	}
+}
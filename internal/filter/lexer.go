@@ -0,0 +1,97 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// describe renders the token the way it should appear in an error message
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of input"
+	}
+	return t.value
+}
+
+// operatorChars are the characters that may form a comparison operator
+const operatorChars = ":=!<>"
+
+// lex splits the input into tokens. Positions are 1-based rune offsets so
+// they can be shown to users as is.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: i + 1})
+			i++
+		case r == '"':
+			start := i
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, &Error{Pos: start + 1, Token: string(runes[start:]), Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokenString, value: b.String(), pos: start + 1})
+			i++
+		case len(tokens) > 0 && tokens[len(tokens)-1].kind == tokenOperator:
+			// Values may hold operator characters, as in the colons of a
+			// timestamp, so they run until whitespace or a closing paren
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != ')' {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[start:i]), pos: start + 1})
+		case strings.ContainsRune(operatorChars, r):
+			start := i
+			for i < len(runes) && strings.ContainsRune(operatorChars, runes[i]) {
+				i++
+			}
+			op := string(runes[start:i])
+			if _, ok := operators[op]; !ok {
+				return nil, &Error{Pos: start + 1, Token: op, Msg: "unknown operator"}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: op, pos: start + 1})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) &&
+				!strings.ContainsRune(operatorChars+`()"`, runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[start:i]), pos: start + 1})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
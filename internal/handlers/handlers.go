@@ -1,19 +1,66 @@
 package handlers
 
 import (
+	"context"
+	"net/http"
+
+	"task-management/internal/collab"
+	"task-management/internal/config"
 	v1 "task-management/internal/handlers/v1"
 	"task-management/internal/services"
+	"task-management/internal/stream"
+	"task-management/internal/web/gql"
+	"task-management/internal/web/httpx"
 
 	"github.com/go-playground/validator"
 )
 
+// unbounded lists the routes of long-lived responses, which the request
+// timeout would cut short
+var unbounded = map[string]bool{
+	"/api/events":       true,
+	"/api/ws":           true,
+	"/api/tasks/export": true,
+	"/api/calendar.ics": true,
+}
+
 type Handler struct {
-	V1 v1.HandlerV1
+	V1      v1.HandlerV1
+	GraphQL http.Handler
+
+	cfg config.HTTP
 }
 
-func New(service services.Service, validate *validator.Validate) *Handler {
-	return &Handler{
-		V1: v1.New(service, validate),
+func New(service services.Service, validate *validator.Validate, events *stream.Broker, hub *collab.Hub, cfg config.HTTP) (*Handler, error) {
+	graphql, err := gql.NewHandler(service, validate)
+	if err != nil {
+		return nil, err
 	}
 
+	return &Handler{
+		V1:      v1.New(service, validate, events, hub, cfg),
+		GraphQL: graphql,
+		cfg:     cfg,
+	}, nil
+}
+
+// Timeout cancels the context of a request once the configured request
+// timeout has passed, stopping the service calls and queries made for it.
+// Event streams, WebSockets, exports and calendar feeds run until the client
+// leaves.
+func (h *Handler) Timeout(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.cfg.RequestTimeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if unbounded[httpx.Route(r)] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
 }
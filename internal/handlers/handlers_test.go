@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"task-management/internal/config"
+
+	"github.com/gorilla/mux"
+)
+
+func TestHandler_Timeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		path         string
+		wantDeadline bool
+	}{
+		{name: "bounded route", timeout: time.Second, path: "/api/tasks", wantDeadline: true},
+		{name: "event stream", timeout: time.Second, path: "/api/events"},
+		{name: "export", timeout: time.Second, path: "/api/tasks/export"},
+		{name: "disabled", path: "/api/tasks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{cfg: config.HTTP{RequestTimeout: tt.timeout}}
+
+			var hasDeadline bool
+			router := mux.NewRouter()
+			router.Use(h.Timeout)
+			router.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+				_, hasDeadline = r.Context().Deadline()
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", tt.path, nil))
+			if hasDeadline != tt.wantDeadline {
+				t.Errorf("request deadline set = %v, want %v", hasDeadline, tt.wantDeadline)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"task-management/internal/entities"
+	"task-management/internal/ical"
+	"task-management/internal/logging"
+	"task-management/internal/web/httpx"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+)
+
+// calendarComponents maps the component query parameter to calendar entries
+var calendarComponents = map[string]ical.Component{
+	"event": ical.Event,
+	"todo":  ical.Todo,
+}
+
+// GetCalendarFeed godoc
+// @Summary Task calendar feed
+// @Description iCalendar (RFC 5545) feed of the tasks with a due date, for subscribing from calendar clients. Each task keeps the same UID across refreshes so clients update entries instead of duplicating them.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token query string true "Calendar token"
+// @Param status query string false "Comma separated task statuses to include, e.g. Pending,InProgress"
+// @Param component query string false "Publish tasks as events or to-dos" Enums(event,todo) default(event)
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/calendar.ics [get]
+func (h *handlerV1) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	statuses, err := parseStatusList(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opts := entities.CalendarFeedOptions{Token: query.Get("token"), Statuses: statuses}
+
+	component := ical.Event
+	if raw := query.Get("component"); raw != "" {
+		var ok bool
+		if component, ok = calendarComponents[raw]; !ok {
+			http.Error(w, "Invalid component, expected event or todo", http.StatusBadRequest)
+			return
+		}
+	}
+
+	httpx.Unbound(w)
+
+	// The calendar is only started once the token is accepted, so errors
+	// raised before the first task can still be reported with a status code
+	var calendar *ical.Writer
+	start := func() {
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, no-cache")
+		calendar = ical.NewWriter(w, component, "Tasks")
+	}
+
+	err = h.Service.CalendarFeed(r.Context(), opts, func(task *entities.TaskResponse) error {
+		if calendar == nil {
+			start()
+		}
+		return calendar.Write(task)
+	})
+	if err != nil {
+		if calendar == nil {
+			http.Error(w, err.Error(), statusFor(err))
+			return
+		}
+		logging.FromContext(r.Context()).Error("calendar feed aborted", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+
+	if calendar == nil {
+		start()
+	}
+	if err := calendar.Close(); err != nil {
+		logging.FromContext(r.Context()).Error("calendar feed aborted", "error", err)
+	}
+}
+
+// GetCalendarTokens godoc
+// @Summary Get calendar tokens
+// @Description Get the caller's calendar feed tokens. The token secrets are not returned.
+// @Tags calendar
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Success 200 {array} entities.CalendarTokenResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/calendar/tokens [get]
+func (h *handlerV1) GetCalendarTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.Service.GetCalendarTokens(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// CreateCalendarToken godoc
+// @Summary Create a calendar token
+// @Description Issue a token for the calendar feed. The token is only shown in this response.
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param token body entities.CalendarTokenRequest true "Calendar token request body"
+// @Success 201 {object} entities.CalendarTokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/calendar/tokens [post]
+func (h *handlerV1) CreateCalendarToken(w http.ResponseWriter, r *http.Request) {
+	var req entities.CalendarTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.Service.CreateCalendarToken(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(token)
+}
+
+// DeleteCalendarToken godoc
+// @Summary Revoke a calendar token
+// @Description Revoke one of the caller's calendar tokens
+// @Tags calendar
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param id path string true "Calendar token ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/calendar/tokens/{id} [delete]
+func (h *handlerV1) DeleteCalendarToken(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid calendar token ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.DeleteCalendarToken(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/web/httpx"
+)
+
+// CreateCollabTicket godoc
+// @Summary Create a collaboration ticket
+// @Description Issue a short-lived ticket identifying the caller on the collaboration channel. Browsers cannot send the gateway headers when opening WebSockets, so they pass the ticket in the ticket query parameter of /api/ws instead.
+// @Tags events
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param X-Project-ID header string false "Caller project ID"
+// @Success 201 {object} entities.CollabTicketResponse
+// @Failure 401 {object} map[string]string
+// @Router /api/ws/tickets [post]
+func (h *handlerV1) CreateCollabTicket(w http.ResponseWriter, r *http.Request) {
+	caller, ok := auth.FromContext(r.Context())
+	if !ok {
+		http.Error(w, entities.ErrUnauthorized.Error(), statusFor(entities.ErrUnauthorized))
+		return
+	}
+
+	ticket, expiresAt := h.Collab.IssueTicket(caller)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entities.CollabTicketResponse{Ticket: ticket, ExpiresAt: expiresAt})
+}
+
+// Collaborate godoc
+// @Summary Collaboration channel
+// @Description WebSocket channel for boards. Clients send JSON messages to subscribe to and unsubscribe from "task:<id>" or "project:<id>" topics, to flag that they are editing a subscribed topic and to ping. The server answers with the users viewing each topic (presence), the task events of subscribed topics and pongs. Presence is kept per server instance. Browsers identify with a ticket from /api/ws/tickets and must come from an allowed origin.
+// @Tags events
+// @Param ticket query string false "Collaboration ticket, instead of the gateway headers"
+// @Param X-User-ID header string false "Caller user ID"
+// @Param X-Project-ID header string false "Caller project ID"
+// @Success 101 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/ws [get]
+func (h *handlerV1) Collaborate(w http.ResponseWriter, r *http.Request) {
+	caller, ok := h.Collab.Caller(r)
+	if !ok {
+		http.Error(w, entities.ErrUnauthorized.Error(), statusFor(entities.ErrUnauthorized))
+		return
+	}
+
+	httpx.Unbound(w)
+	h.Collab.Serve(w, r, caller)
+}
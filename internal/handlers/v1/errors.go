@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"task-management/internal/entities"
+)
+
+// statusFor maps service errors to the HTTP status reported to the client
+func statusFor(err error) int {
+	switch entities.KindOf(err) {
+	case entities.KindInvalid:
+		return http.StatusBadRequest
+	case entities.KindUnauthenticated:
+		return http.StatusUnauthorized
+	case entities.KindForbidden:
+		return http.StatusForbidden
+	case entities.KindNotFound:
+		return http.StatusNotFound
+	}
+
+	// The request timeout ran out before the service was done
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
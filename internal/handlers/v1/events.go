@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/stream"
+	"task-management/internal/web/httpx"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// heartbeatInterval keeps idle streams from being closed by proxies
+	heartbeatInterval = 15 * time.Second
+
+	// reconnectDelay is the retry delay suggested to clients, in milliseconds
+	reconnectDelay = 3000
+)
+
+// StreamEvents godoc
+// @Summary Stream task events
+// @Description Server-Sent Events stream of task changes (task.created, task.updated, task.deleted, task.status_changed) visible to the caller. Reconnecting with Last-Event-ID replays the missed events while they are still in the recent event log; otherwise a reset event tells the client to reload its tasks. The stream ends when the client falls too far behind, and the client should then reconnect.
+// @Tags events
+// @Produce text/event-stream
+// @Param X-User-ID header string false "Caller user ID"
+// @Param X-Project-ID header string false "Caller project ID"
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param project_id query string false "Only events of tasks in this project"
+// @Param status query string false "Comma separated task statuses; only events of tasks in, or leaving, these statuses"
+// @Success 200 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/events [get]
+func (h *handlerV1) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	filter, err := parseStreamFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	var lastEventID *int64
+	if raw := r.Header.Get("Last-Event-ID"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			// An ID we never sent cannot be resumed from
+			id = -1
+		}
+		lastEventID = &id
+	}
+
+	sub, backlog, resumed := h.Events.Subscribe(lastEventID, filter.Match)
+	defer sub.Close()
+
+	httpx.Unbound(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay)
+	if !resumed {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	for _, event := range backlog {
+		writeSSE(w, event)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			writeSSE(w, event)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+// parseStreamFilter reads the event filters of a streaming request, refusing
+// projects the caller cannot see
+func parseStreamFilter(r *http.Request) (stream.Filter, error) {
+	caller, _ := auth.FromContext(r.Context())
+	filter := stream.Filter{Caller: caller}
+
+	if raw := r.URL.Query().Get("project_id"); raw != "" {
+		projectID, err := uuid.FromString(raw)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid project_id", entities.ErrInvalidFilter)
+		}
+		if !caller.CanSeeProject(&projectID) {
+			return filter, entities.ErrForbidden
+		}
+		filter.ProjectID = &projectID
+	}
+
+	statuses, err := parseStatusList(r)
+	if err != nil {
+		return filter, fmt.Errorf("%w: %v", entities.ErrInvalidFilter, err)
+	}
+	filter.Statuses = statuses
+
+	return filter, nil
+}
+
+// writeSSE writes an event in the text/event-stream format. Event data is
+// single line JSON, so it fits in one data field.
+func writeSSE(w http.ResponseWriter, event stream.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+}
@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"task-management/internal/entities"
+)
+
+const (
+	exportCSV   = "csv"
+	exportJSONL = "jsonl"
+	exportExcel = "excel"
+)
+
+// exportFlushEvery is the number of rows written between two flushes
+const exportFlushEvery = 100
+
+// exportColumns are the CSV columns of an export, in order
+var exportColumns = []string{"id", "title", "description", "status", "due_date", "created_at", "updated_at", "labels"}
+
+// exportWriter encodes exported tasks in one of the supported formats,
+// flushing regularly so rows reach the client while they are read. The
+// download headers are only sent along with the first row, so errors
+// happening before it are answered as usual.
+type exportWriter struct {
+	contentType string
+	extension   string
+	filename    string
+	started     bool
+
+	w       http.ResponseWriter
+	flusher http.Flusher
+	rows    int
+
+	csv   *csv.Writer
+	json  *json.Encoder
+	excel bool
+}
+
+func newExportWriter(w http.ResponseWriter, format string) (*exportWriter, error) {
+	ew := &exportWriter{w: w}
+	ew.flusher, _ = w.(http.Flusher)
+
+	switch format {
+	case exportCSV, exportExcel:
+		ew.contentType = "text/csv; charset=utf-8"
+		ew.extension = "csv"
+		ew.csv = csv.NewWriter(w)
+		ew.excel = format == exportExcel
+		ew.csv.UseCRLF = ew.excel
+	case exportJSONL:
+		ew.contentType = "application/x-ndjson"
+		ew.extension = "jsonl"
+		ew.json = json.NewEncoder(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q, expected csv, jsonl or excel", format)
+	}
+
+	ew.filename = fmt.Sprintf("tasks-%s.%s", time.Now().UTC().Format("20060102-150405"), ew.extension)
+	return ew, nil
+}
+
+// start sends the download headers and writes what comes before the first
+// row
+func (ew *exportWriter) start() error {
+	ew.started = true
+	ew.w.Header().Set("Content-Type", ew.contentType)
+	ew.w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": ew.filename}))
+	if ew.csv == nil {
+		return nil
+	}
+
+	if ew.excel {
+		// Excel only detects UTF-8 with a byte order mark
+		if _, err := io.WriteString(ew.w, "\ufeff"); err != nil {
+			return err
+		}
+	}
+	return ew.csv.Write(exportColumns)
+}
+
+func (ew *exportWriter) write(task *entities.TaskResponse) error {
+	if !ew.started {
+		if err := ew.start(); err != nil {
+			return err
+		}
+	}
+
+	if ew.json != nil {
+		if err := ew.json.Encode(task); err != nil {
+			return err
+		}
+	} else {
+		dueDate := ""
+		if task.DueDate != nil {
+			dueDate = task.DueDate.Format(time.RFC3339)
+		}
+
+		record := []string{
+			task.ID.String(),
+			task.Title,
+			task.Description,
+			string(task.Status),
+			dueDate,
+			task.CreatedAt.Format(time.RFC3339),
+			task.UpdatedAt.Format(time.RFC3339),
+			strings.Join(task.Labels, ";"),
+		}
+		for i := range record {
+			record[i] = neutralizeFormula(record[i])
+		}
+		if err := ew.csv.Write(record); err != nil {
+			return err
+		}
+	}
+
+	ew.rows++
+	if ew.rows%exportFlushEvery == 0 {
+		return ew.flush()
+	}
+	return nil
+}
+
+// close writes the header of empty exports and flushes the remaining rows
+func (ew *exportWriter) close() error {
+	if !ew.started {
+		if err := ew.start(); err != nil {
+			return err
+		}
+	}
+	return ew.flush()
+}
+
+func (ew *exportWriter) flush() error {
+	if ew.csv != nil {
+		ew.csv.Flush()
+		if err := ew.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if ew.flusher != nil {
+		ew.flusher.Flush()
+	}
+	return nil
+}
+
+// neutralizeFormula prevents spreadsheets from evaluating cell values that
+// look like formulas
+func neutralizeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"task-management/internal/entities"
+	"task-management/internal/services/mocks"
+
+	"github.com/go-playground/validator"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_handlerV1_ExportTasks(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+	task := &entities.TaskResponse{
+		ID:          taskID,
+		Title:       "=HYPERLINK(\"http://example.com\")",
+		Description: "-1+2",
+		Status:      entities.StatusPending,
+		Labels:      []string{"@team"},
+	}
+
+	export := func(tasks ...*entities.TaskResponse) func(context.Context, entities.TaskListOptions, func(*entities.TaskResponse) error) error {
+		return func(ctx context.Context, opts entities.TaskListOptions, fn func(*entities.TaskResponse) error) error {
+			for _, task := range tasks {
+				if err := fn(task); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name           string
+		export         interface{}
+		wantStatus     int
+		wantAttachment bool
+		wantBody       []string
+	}{
+		{
+			name:           "formulas are neutralized",
+			export:         export(task),
+			wantStatus:     http.StatusOK,
+			wantAttachment: true,
+			wantBody:       []string{`"'=HYPERLINK(""http://example.com"")",'-1+2,Pending`, ",'@team\n"},
+		},
+		{
+			name:           "empty export",
+			export:         export(),
+			wantStatus:     http.StatusOK,
+			wantAttachment: true,
+			wantBody:       []string{"id,title,description,status,due_date,created_at,updated_at,labels\n"},
+		},
+		{
+			name:       "error before the first row",
+			export:     errors.New("db error"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   []string{"db error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mocks.Service{}
+			service.On("ExportTasks", mock.Anything, mock.Anything, mock.Anything).Return(tt.export)
+
+			h := &handlerV1{Service: service, Validate: validator.New()}
+			w := httptest.NewRecorder()
+			h.ExportTasks(w, httptest.NewRequest("GET", "/api/tasks/export", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			disposition := w.Header().Get("Content-Disposition")
+			if got := strings.HasPrefix(disposition, "attachment"); got != tt.wantAttachment {
+				t.Errorf("Content-Disposition = %q, want attachment %v", disposition, tt.wantAttachment)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("body = %q, want it to contain %q", w.Body.String(), want)
+				}
+			}
+		})
+	}
+}
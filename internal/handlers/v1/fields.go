@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"task-management/internal/entities"
+)
+
+// parseFieldsAndIncludes reads the fields and include query parameters.
+// Embedded relations are always part of the selected fields, and selecting
+// a relation as a field embeds it.
+func parseFieldsAndIncludes(r *http.Request) ([]string, entities.TaskIncludes, error) {
+	fields, err := entities.ParseTaskFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		return nil, entities.TaskIncludes{}, err
+	}
+
+	include, err := entities.ParseTaskIncludes(r.URL.Query().Get("include"))
+	if err != nil {
+		return nil, entities.TaskIncludes{}, err
+	}
+
+	hasLabels := false
+	for _, field := range fields {
+		hasLabels = hasLabels || field == "labels"
+	}
+	if hasLabels {
+		include.Labels = true
+	} else if include.Labels && len(fields) > 0 {
+		fields = append(fields, "labels")
+	}
+
+	return fields, include, nil
+}
+
+// selectFields keeps only the given JSON fields of v, which must encode to
+// an object or an array of objects. Without fields v is returned as is.
+func selectFields(v interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 && data[0] == '[' {
+		var objects []map[string]json.RawMessage
+		if err := json.Unmarshal(data, &objects); err != nil {
+			return nil, err
+		}
+		for i := range objects {
+			objects[i] = pick(objects[i], fields)
+		}
+		return objects, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return pick(object, fields), nil
+}
+
+func pick(object map[string]json.RawMessage, fields []string) map[string]json.RawMessage {
+	picked := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := object[field]; ok {
+			picked[field] = value
+		}
+	}
+	return picked
+}
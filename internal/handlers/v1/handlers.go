@@ -3,7 +3,10 @@ package v1
 import (
 	"net/http"
 
+	"task-management/internal/collab"
+	"task-management/internal/config"
 	"task-management/internal/services"
+	"task-management/internal/stream"
 
 	"github.com/go-playground/validator"
 )
@@ -11,6 +14,9 @@ import (
 type handlerV1 struct {
 	Service  services.Service
 	Validate *validator.Validate
+	Events   *stream.Broker
+	Collab   *collab.Hub
+	Config   config.HTTP
 }
 
 type HandlerV1 interface {
@@ -18,11 +24,41 @@ type HandlerV1 interface {
 	// Task handlers
 	GetTaskByID(w http.ResponseWriter, r *http.Request)
 	GetAllTasks(w http.ResponseWriter, r *http.Request)
+	ExportTasks(w http.ResponseWriter, r *http.Request)
+	SearchTasks(w http.ResponseWriter, r *http.Request)
 	CreateTask(w http.ResponseWriter, r *http.Request)
 	UpdateTask(w http.ResponseWriter, r *http.Request)
 	DeleteTask(w http.ResponseWriter, r *http.Request)
+	BulkTasks(w http.ResponseWriter, r *http.Request)
+	ImportTasks(w http.ResponseWriter, r *http.Request)
+
+	// View handlers
+	GetViews(w http.ResponseWriter, r *http.Request)
+	GetViewByID(w http.ResponseWriter, r *http.Request)
+	CreateView(w http.ResponseWriter, r *http.Request)
+	UpdateView(w http.ResponseWriter, r *http.Request)
+	DeleteView(w http.ResponseWriter, r *http.Request)
+
+	// Calendar handlers
+	GetCalendarFeed(w http.ResponseWriter, r *http.Request)
+	GetCalendarTokens(w http.ResponseWriter, r *http.Request)
+	CreateCalendarToken(w http.ResponseWriter, r *http.Request)
+	DeleteCalendarToken(w http.ResponseWriter, r *http.Request)
+
+	// Webhook handlers
+	GetWebhooks(w http.ResponseWriter, r *http.Request)
+	GetWebhookByID(w http.ResponseWriter, r *http.Request)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	UpdateWebhook(w http.ResponseWriter, r *http.Request)
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	GetWebhookDeliveries(w http.ResponseWriter, r *http.Request)
+
+	// Event handlers
+	StreamEvents(w http.ResponseWriter, r *http.Request)
+	CreateCollabTicket(w http.ResponseWriter, r *http.Request)
+	Collaborate(w http.ResponseWriter, r *http.Request)
 }
 
-func New(s services.Service, v *validator.Validate) HandlerV1 {
-	return &handlerV1{Service: s, Validate: v}
+func New(s services.Service, v *validator.Validate, events *stream.Broker, hub *collab.Hub, cfg config.HTTP) HandlerV1 {
+	return &handlerV1{Service: s, Validate: v, Events: events, Collab: hub, Config: cfg}
 }
@@ -2,9 +2,17 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+
 	"task-management/internal/entities"
+	"task-management/internal/filter"
+	"task-management/internal/importer"
+	"task-management/internal/logging"
+	"task-management/internal/web/httpx"
 
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/mux"
@@ -12,51 +20,186 @@ import (
 
 // GetAllTasks godoc
 // @Summary Get all tasks
-// @Description Get all tasks with pagination and optional status filter
+// @Description Get all tasks with pagination, optional status filter, filter expression and sorting
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
-// @Param pageSize query int false "Page size" default(10)
+// @Param pageSize query int false "Page size, at most 100" default(10)
 // @Param status query string false "Task status filter" Enums(Pending,InProgress,Completed,Cancelled)
+// @Param sort query string false "Comma separated sort keys, prefix with - for descending (title, status, due_date, created_at, updated_at)" default(-created_at)
+// @Param filter query string false "Filter expression, e.g. status:InProgress AND due<2026-11-01 AND label:backend"
+// @Param view query string false "Saved view ID providing defaults for the other parameters"
+// @Param fields query string false "Comma separated fields to return (id, title, description, status, due_date, created_at, updated_at, labels)"
+// @Param include query string false "Comma separated relations to embed" Enums(labels)
 // @Success 200 {array} entities.TaskResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/tasks [get]
 func (h *handlerV1) GetAllTasks(w http.ResponseWriter, r *http.Request) {
-	page := 1
-	pageSize := 10
+	opts, err := h.parseListOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
 
-	pageParam := r.URL.Query().Get("page")
-	if pageParam != "" {
-		pageInt, err := strconv.Atoi(pageParam)
-		if err == nil && pageInt > 0 {
-			page = pageInt
-		}
+	fields, include, err := parseFieldsAndIncludes(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
+	opts.Include = include
 
-	pageSizeParam := r.URL.Query().Get("pageSize")
-	if pageSizeParam != "" {
-		pageSizeInt, err := strconv.Atoi(pageSizeParam)
-		if err == nil && pageSizeInt > 0 {
-			pageSize = pageSizeInt
+	tasks, err := h.Service.GetAllTasks(r.Context(), opts)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	response, err := selectFields(tasks, fields)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseListOptions reads the pagination, status, sort, filter and view query
+// parameters shared by the task listing endpoints
+func (h *handlerV1) parseListOptions(r *http.Request) (entities.TaskListOptions, error) {
+	query := r.URL.Query()
+	page, pageSize := parsePagination(r)
+	status := parseStatus(r)
+	sortParam := query.Get("sort")
+	filterParam := query.Get("filter")
+
+	// A saved view provides defaults for every parameter not given explicitly
+	if viewParam := query.Get("view"); viewParam != "" {
+		viewID, err := uuid.FromString(viewParam)
+		if err != nil {
+			return entities.TaskListOptions{}, fmt.Errorf("%w: invalid view ID", entities.ErrInvalidView)
+		}
+
+		view, err := h.Service.GetViewByID(r.Context(), viewID)
+		if err != nil {
+			return entities.TaskListOptions{}, err
+		}
+
+		if status == nil {
+			status = view.Status
+		}
+		if sortParam == "" {
+			sortParam = view.Sort
+		}
+		if filterParam == "" {
+			filterParam = view.Filter
+		}
+		if query.Get("pageSize") == "" && view.PageSize > 0 {
+			pageSize = view.PageSize
 		}
 	}
 
-	var status *entities.TaskStatus
-	statusParam := r.URL.Query().Get("status")
-	if statusParam != "" {
-		taskStatus := entities.TaskStatus(statusParam)
-		if taskStatus == entities.StatusPending ||
-			taskStatus == entities.StatusInProgress ||
-			taskStatus == entities.StatusCompleted ||
-			taskStatus == entities.StatusCancelled {
-			status = &taskStatus
+	sort, err := entities.ParseTaskSort(sortParam)
+	if err != nil {
+		return entities.TaskListOptions{}, err
+	}
+
+	taskFilter, err := filter.Parse(filterParam)
+	if err != nil {
+		return entities.TaskListOptions{}, err
+	}
+
+	return entities.TaskListOptions{
+		Page:     page,
+		PageSize: pageSize,
+		Status:   status,
+		Sort:     sort,
+		Filter:   taskFilter,
+	}, nil
+}
+
+// ExportTasks godoc
+// @Summary Export tasks
+// @Description Stream every task matching the same filters as GetAllTasks as CSV, JSON Lines or Excel-friendly CSV (UTF-8 byte order mark, CRLF line endings and neutralized formulas). Pagination is ignored.
+// @Tags tasks
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "Export format" Enums(csv,jsonl,excel) default(csv)
+// @Param status query string false "Task status filter" Enums(Pending,InProgress,Completed,Cancelled)
+// @Param sort query string false "Comma separated sort keys, prefix with - for descending (title, status, due_date, created_at, updated_at)" default(-created_at)
+// @Param filter query string false "Filter expression, e.g. status:InProgress AND due<2026-11-01 AND label:backend"
+// @Param view query string false "Saved view ID providing defaults for the other parameters"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tasks/export [get]
+func (h *handlerV1) ExportTasks(w http.ResponseWriter, r *http.Request) {
+	opts, err := h.parseListOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+	opts.Page, opts.PageSize = 0, 0
+	opts.Include = entities.TaskIncludes{Labels: true}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportCSV
+	}
+
+	writer, err := newExportWriter(w, format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	httpx.Unbound(w)
+
+	err = h.Service.ExportTasks(r.Context(), opts, writer.write)
+	if err == nil {
+		err = writer.close()
+	}
+	if err != nil {
+		if !writer.started {
+			http.Error(w, err.Error(), statusFor(err))
+			return
 		}
+		// The status line is already sent, so the best we can do is to cut
+		// the download short and let the client notice the truncated file
+		logging.FromContext(r.Context()).Error("export aborted", "error", err)
+		panic(http.ErrAbortHandler)
 	}
+}
+
+// SearchTasks godoc
+// @Summary Search tasks
+// @Description Full-text search over task titles and descriptions, ranked by relevance with highlighted snippets. Highlights are HTML: the task text is escaped and matches are wrapped in <mark>. Every word matches as a prefix.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size, at most 100" default(10)
+// @Param status query string false "Task status filter" Enums(Pending,InProgress,Completed,Cancelled)
+// @Success 200 {array} entities.TaskSearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tasks/search [get]
+func (h *handlerV1) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := parsePagination(r)
 
-	tasks, err := h.Service.GetAllTasks(r.Context(), page, pageSize, status)
+	tasks, err := h.Service.SearchTasks(r.Context(), entities.TaskSearchOptions{
+		Query:    r.URL.Query().Get("q"),
+		Page:     page,
+		PageSize: pageSize,
+		Status:   parseStatus(r),
+	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), statusFor(err))
 		return
 	}
 
@@ -71,9 +214,12 @@ func (h *handlerV1) GetAllTasks(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID"
+// @Param fields query string false "Comma separated fields to return (id, title, description, status, due_date, created_at, updated_at, labels)"
+// @Param include query string false "Comma separated relations to embed" Enums(labels)
 // @Success 200 {object} entities.TaskResponse
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
 // @Router /api/tasks/{id} [get]
 func (h *handlerV1) GetTaskByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -83,14 +229,26 @@ func (h *handlerV1) GetTaskByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	task, err := h.Service.GetTaskByID(r.Context(), id)
+	fields, include, err := parseFieldsAndIncludes(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.Service.GetTaskByID(r.Context(), id, include)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	response, err := selectFields(task, fields)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(task)
+	json.NewEncoder(w).Encode(response)
 }
 
 // CreateTask godoc
@@ -100,8 +258,9 @@ func (h *handlerV1) GetTaskByID(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param task body entities.TaskRequest true "Task request body"
-// @Success 201
+// @Success 201 {object} entities.TaskResponse
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/tasks [post]
 func (h *handlerV1) CreateTask(w http.ResponseWriter, r *http.Request) {
@@ -116,12 +275,15 @@ func (h *handlerV1) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.Service.CreateTask(r.Context(), &req); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	task, err := h.Service.CreateTask(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(task)
 }
 
 // UpdateTask godoc
@@ -134,6 +296,8 @@ func (h *handlerV1) CreateTask(w http.ResponseWriter, r *http.Request) {
 // @Param task body entities.TaskRequest true "Task request body"
 // @Success 200
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/tasks/{id} [put]
 func (h *handlerV1) UpdateTask(w http.ResponseWriter, r *http.Request) {
@@ -156,7 +320,7 @@ func (h *handlerV1) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.Service.UpdateTask(r.Context(), id, &req); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), statusFor(err))
 		return
 	}
 
@@ -172,6 +336,8 @@ func (h *handlerV1) UpdateTask(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "Task ID"
 // @Success 204
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/tasks/{id} [delete]
 func (h *handlerV1) DeleteTask(w http.ResponseWriter, r *http.Request) {
@@ -183,9 +349,164 @@ func (h *handlerV1) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.Service.DeleteTask(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), statusFor(err))
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// BulkTasks godoc
+// @Summary Apply task operations in bulk
+// @Description Create tasks, change their status, delete them or add and remove labels in one request of at most 100 items.
+// @Description Requests run in a single transaction and stop at the first failure unless atomic is false, in which case every item is applied on its own.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param bulk body entities.BulkRequest true "Bulk request body"
+// @Success 200 {object} entities.BulkResponse
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} entities.BulkResponse
+// @Failure 500 {object} map[string]string
+// @Router /api/tasks/bulk [post]
+func (h *handlerV1) BulkTasks(w http.ResponseWriter, r *http.Request) {
+	var req entities.BulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.Service.BulkTasks(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if req.IsAtomic() && response.Failed > 0 {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// ImportTasks godoc
+// @Summary Import tasks
+// @Description Import tasks from a CSV file or a JSON array of task requests. Every row is validated and nothing is imported when a row is invalid.
+// @Description CSV files need a header line; columns are matched by name unless a mapping such as title=Name,due_date=Due is given. Labels are separated by semicolons.
+// @Tags tasks
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param format query string false "File format, defaults to the request content type" Enums(csv,json)
+// @Param mapping query string false "CSV column mapping as field=header pairs"
+// @Param dryRun query bool false "Validate without importing"
+// @Success 200 {object} entities.ImportResult
+// @Success 201 {object} entities.ImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 422 {object} entities.ImportResult
+// @Failure 500 {object} map[string]string
+// @Router /api/tasks/import [post]
+func (h *handlerV1) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = importer.FormatJSON
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+			format = importer.FormatCSV
+		}
+	}
+
+	dryRun, _ := strconv.ParseBool(query.Get("dryRun"))
+
+	body := http.MaxBytesReader(w, r.Body, int64(h.Config.MaxImportSize))
+	result, err := importer.Run(r.Context(), h.Service, h.Validate, body, importer.Options{
+		Format:  format,
+		Mapping: query.Get("mapping"),
+		DryRun:  dryRun,
+	})
+	if err != nil {
+		code := statusFor(err)
+		if errors.Is(err, importer.ErrInvalidFile) {
+			code = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case len(result.Errors) > 0:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	case !result.DryRun:
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+// parsePagination reads the page and pageSize query parameters, falling back
+// to the first page of ten tasks when they are missing or invalid. Page sizes
+// are capped at entities.MaxPageSize.
+func parsePagination(r *http.Request) (int, int) {
+	page := 1
+	pageSize := 10
+
+	pageParam := r.URL.Query().Get("page")
+	if pageParam != "" {
+		pageInt, err := strconv.Atoi(pageParam)
+		if err == nil && pageInt > 0 {
+			page = pageInt
+		}
+	}
+
+	pageSizeParam := r.URL.Query().Get("pageSize")
+	if pageSizeParam != "" {
+		pageSizeInt, err := strconv.Atoi(pageSizeParam)
+		if err == nil && pageSizeInt > 0 {
+			pageSize = min(pageSizeInt, entities.MaxPageSize)
+		}
+	}
+
+	return page, pageSize
+}
+
+// parseStatus reads the status query parameter, ignoring unknown values
+func parseStatus(r *http.Request) *entities.TaskStatus {
+	statusParam := r.URL.Query().Get("status")
+	if statusParam == "" {
+		return nil
+	}
+
+	taskStatus := entities.TaskStatus(statusParam)
+	if taskStatus.Valid() {
+		return &taskStatus
+	}
+
+	return nil
+}
+
+// parseStatusList parses the comma separated statuses of the status query
+// parameter
+func parseStatusList(r *http.Request) ([]entities.TaskStatus, error) {
+	raw := r.URL.Query().Get("status")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var statuses []entities.TaskStatus
+	for _, s := range strings.Split(raw, ",") {
+		status := entities.TaskStatus(strings.TrimSpace(s))
+		if !status.Valid() {
+			return nil, fmt.Errorf("invalid status %q", s)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
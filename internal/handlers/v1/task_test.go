@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"task-management/internal/config"
+	"task-management/internal/entities"
+	"task-management/internal/services/mocks"
+
+	"github.com/go-playground/validator"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_handlerV1_CreateTask(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+	created := &entities.TaskResponse{ID: taskID, Title: "Test Task", Status: entities.StatusPending, Labels: []string{"docs"}}
+
+	tests := []struct {
+		name       string
+		body       string
+		task       *entities.TaskResponse
+		err        error
+		wantStatus int
+	}{
+		{name: "created", body: `{"title":"Test Task","status":"Pending","labels":["docs"]}`, task: created, wantStatus: http.StatusCreated},
+		{name: "invalid body", body: `{"title":`, wantStatus: http.StatusBadRequest},
+		{name: "forbidden", body: `{"title":"Test Task","status":"Pending"}`, err: entities.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "internal", body: `{"title":"Test Task","status":"Pending"}`, err: errors.New("db error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mocks.Service{}
+			service.On("CreateTask", mock.Anything, mock.Anything).Return(tt.task, tt.err)
+
+			h := &handlerV1{Service: service, Validate: validator.New()}
+			w := httptest.NewRecorder()
+			h.CreateTask(w, httptest.NewRequest("POST", "/api/tasks", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.task == nil {
+				return
+			}
+
+			// Clients use the created task, its ID in particular
+			var got entities.TaskResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid body %q: %v", w.Body.String(), err)
+			}
+			if got.ID != taskID || got.Title != created.Title || len(got.Labels) != 1 {
+				t.Errorf("body = %+v, want %+v", got, created)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+		})
+	}
+}
+
+func Test_handlerV1_UpdateTask_DeleteTask_errors(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "not found", err: entities.ErrTaskNotFound, wantStatus: http.StatusNotFound},
+		{name: "forbidden", err: entities.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "internal", err: errors.New("db error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mocks.Service{}
+			service.On("UpdateTask", mock.Anything, taskID, mock.Anything).Return(tt.err)
+			service.On("DeleteTask", mock.Anything, taskID).Return(tt.err)
+
+			h := &handlerV1{Service: service, Validate: validator.New()}
+			vars := map[string]string{"id": taskID.String()}
+
+			w := httptest.NewRecorder()
+			body := strings.NewReader(`{"title":"Test Task","status":"Pending"}`)
+			h.UpdateTask(w, mux.SetURLVars(httptest.NewRequest("PUT", "/api/tasks/"+taskID.String(), body), vars))
+			if w.Code != tt.wantStatus {
+				t.Errorf("UpdateTask() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			w = httptest.NewRecorder()
+			h.DeleteTask(w, mux.SetURLVars(httptest.NewRequest("DELETE", "/api/tasks/"+taskID.String(), nil), vars))
+			if w.Code != tt.wantStatus {
+				t.Errorf("DeleteTask() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_handlerV1_read_errors(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "not found", err: entities.ErrTaskNotFound, wantStatus: http.StatusNotFound},
+		{name: "invalid filter", err: entities.ErrInvalidFilter, wantStatus: http.StatusBadRequest},
+		{name: "timed out", err: context.DeadlineExceeded, wantStatus: http.StatusServiceUnavailable},
+		{name: "internal", err: errors.New("db error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mocks.Service{}
+			service.On("GetTaskByID", mock.Anything, taskID, mock.Anything).Return(nil, tt.err)
+			service.On("GetAllTasks", mock.Anything, mock.Anything).Return(nil, tt.err)
+			service.On("SearchTasks", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			h := &handlerV1{Service: service, Validate: validator.New()}
+
+			w := httptest.NewRecorder()
+			h.GetTaskByID(w, mux.SetURLVars(httptest.NewRequest("GET", "/api/tasks/"+taskID.String(), nil), map[string]string{"id": taskID.String()}))
+			if w.Code != tt.wantStatus {
+				t.Errorf("GetTaskByID() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			w = httptest.NewRecorder()
+			h.GetAllTasks(w, httptest.NewRequest("GET", "/api/tasks", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("GetAllTasks() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			w = httptest.NewRecorder()
+			h.SearchTasks(w, httptest.NewRequest("GET", "/api/tasks/search?q=login", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("SearchTasks() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_handlerV1_ImportTasks_errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "invalid file", body: `{"title":`, wantStatus: http.StatusBadRequest},
+		{name: "forbidden project", body: `[{"title":"Test Task","status":"Pending","project_id":"` + uuid.Must(uuid.NewV4()).String() + `"}]`, err: entities.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "internal", body: `[{"title":"Test Task","status":"Pending"}]`, err: errors.New("db error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mocks.Service{}
+			service.On("ImportTasks", mock.Anything, mock.Anything, false).Return(nil, tt.err)
+
+			h := &handlerV1{Service: service, Validate: validator.New(), Config: config.HTTP{MaxImportSize: 1 << 20}}
+			w := httptest.NewRecorder()
+			h.ImportTasks(w, httptest.NewRequest("POST", "/api/tasks/import", strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Errorf("ImportTasks() status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
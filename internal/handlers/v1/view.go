@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetViews godoc
+// @Summary Get saved views
+// @Description Get the caller's saved views and the views shared with their project
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param X-Project-ID header string false "Caller project ID"
+// @Success 200 {array} entities.ViewResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/views [get]
+func (h *handlerV1) GetViews(w http.ResponseWriter, r *http.Request) {
+	views, err := h.Service.GetViews(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(views)
+}
+
+// GetViewByID godoc
+// @Summary Get a saved view by ID
+// @Description Get a saved view owned by the caller or shared with their project
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param X-Project-ID header string false "Caller project ID"
+// @Param id path string true "View ID"
+// @Success 200 {object} entities.ViewResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/views/{id} [get]
+func (h *handlerV1) GetViewByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	view, err := h.Service.GetViewByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(view)
+}
+
+// CreateView godoc
+// @Summary Create a saved view
+// @Description Save a named combination of filter, status, sort and page size
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param X-Project-ID header string false "Caller project ID"
+// @Param view body entities.ViewRequest true "View request body"
+// @Success 201 {object} entities.ViewResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/views [post]
+func (h *handlerV1) CreateView(w http.ResponseWriter, r *http.Request) {
+	var req entities.ViewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	view, err := h.Service.CreateView(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(view)
+}
+
+// UpdateView godoc
+// @Summary Update a saved view
+// @Description Update a saved view owned by the caller
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param X-Project-ID header string false "Caller project ID"
+// @Param id path string true "View ID"
+// @Param view body entities.ViewRequest true "View request body"
+// @Success 200
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/views/{id} [put]
+func (h *handlerV1) UpdateView(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	var req entities.ViewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.UpdateView(r.Context(), id, &req); err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteView godoc
+// @Summary Delete a saved view
+// @Description Delete a saved view owned by the caller
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param X-Project-ID header string false "Caller project ID"
+// @Param id path string true "View ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/views/{id} [delete]
+func (h *handlerV1) DeleteView(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.DeleteView(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+)
+
+// GetWebhooks godoc
+// @Summary Get webhook subscriptions
+// @Description Get the caller's webhook subscriptions. Signing secrets are not returned.
+// @Tags webhooks
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Success 200 {array} entities.WebhookResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks [get]
+func (h *handlerV1) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.Service.GetWebhooks(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhooks)
+}
+
+// GetWebhookByID godoc
+// @Summary Get a webhook subscription by ID
+// @Description Get one of the caller's webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} entities.WebhookResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/webhooks/{id} [get]
+func (h *handlerV1) GetWebhookByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.Service.GetWebhookByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook subscription
+// @Description Subscribe a URL to task events (task.created, task.updated, task.deleted, task.status_changed; all when events is empty). Payloads are signed with the returned secret, which is only shown in this response.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param webhook body entities.WebhookRequest true "Webhook request body"
+// @Success 201 {object} entities.WebhookResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks [post]
+func (h *handlerV1) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req entities.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.Service.CreateWebhook(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook subscription
+// @Description Update the URL, events or active flag of one of the caller's webhook subscriptions
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param id path string true "Webhook ID"
+// @Param webhook body entities.WebhookRequest true "Webhook request body"
+// @Success 200
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks/{id} [put]
+func (h *handlerV1) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	var req entities.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.UpdateWebhook(r.Context(), id, &req); err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook subscription
+// @Description Delete one of the caller's webhook subscriptions along with its delivery log
+// @Tags webhooks
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks/{id} [delete]
+func (h *handlerV1) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.DeleteWebhook(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get webhook deliveries
+// @Description Get the delivery log of one of the caller's webhook subscriptions, newest first
+// @Tags webhooks
+// @Produce json
+// @Param X-User-ID header string true "Caller user ID"
+// @Param id path string true "Webhook ID"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} entities.WebhookDeliveryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/webhooks/{id}/deliveries [get]
+func (h *handlerV1) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	page, pageSize := parsePagination(r)
+
+	deliveries, err := h.Service.GetWebhookDeliveries(r.Context(), id, page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
@@ -0,0 +1,279 @@
+// Package health serves the probes of the orchestrator and a detailed status
+// page for administrators:
+//
+//	/healthz  the process is alive
+//	/readyz   the dependencies answer and the background workers run
+//	/status   version, uptime, dependency latencies and workers
+package health
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Check reports whether a dependency is usable
+type Check func(ctx context.Context) error
+
+type check struct {
+	name string
+	run  Check
+}
+
+// Checker tracks the dependencies and workers the server needs to serve
+// requests
+type Checker struct {
+	version     string
+	started     time.Time
+	timeout     time.Duration
+	statusToken string
+
+	mu      sync.Mutex
+	checks  []check
+	workers map[string]bool
+}
+
+// New creates a checker. version defaults to the VCS revision the binary was
+// built from. Checks are given timeout to complete. /status requires
+// statusToken as a bearer token and is disabled when it is empty.
+func New(version string, timeout time.Duration, statusToken string) *Checker {
+	if version == "" {
+		version = buildVersion()
+	}
+
+	return &Checker{
+		version:     version,
+		started:     time.Now(),
+		timeout:     timeout,
+		statusToken: statusToken,
+		workers:     make(map[string]bool),
+	}
+}
+
+// AddCheck registers a dependency check, run by /readyz and /status
+func (c *Checker) AddCheck(name string, run Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, check{name: name, run: run})
+}
+
+// Worker records that the named background worker is running until the
+// returned function is called
+func (c *Checker) Worker(name string) (stopped func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.workers[name] = true
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.workers[name] = false
+	}
+}
+
+// Dependency is the outcome of a check
+type Dependency struct {
+	Name      string  `json:"name"`
+	OK        bool    `json:"ok"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Worker is the state of a background worker
+type Worker struct {
+	Name    string `json:"name"`
+	Running bool   `json:"running"`
+}
+
+// Status is the body of /status
+type Status struct {
+	Status       string       `json:"status"`
+	Version      string       `json:"version"`
+	GoVersion    string       `json:"go_version"`
+	StartedAt    time.Time    `json:"started_at"`
+	Uptime       string       `json:"uptime"`
+	Dependencies []Dependency `json:"dependencies"`
+	Workers      []Worker     `json:"workers"`
+}
+
+// run runs every check concurrently and returns their outcomes in
+// registration order, along with the workers by name
+func (c *Checker) run(ctx context.Context) ([]Dependency, []Worker) {
+	c.mu.Lock()
+	checks := append([]check(nil), c.checks...)
+	workers := make([]Worker, 0, len(c.workers))
+	for name, running := range c.workers {
+		workers = append(workers, Worker{Name: name, Running: running})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].Name < workers[j].Name
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	deps := make([]Dependency, len(checks))
+	var wg sync.WaitGroup
+	for i, chk := range checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := chk.run(ctx)
+			deps[i] = Dependency{
+				Name:      chk.name,
+				OK:        err == nil,
+				LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				deps[i].Error = err.Error()
+			}
+		}(i, chk)
+	}
+	wg.Wait()
+
+	return deps, workers
+}
+
+// healthy reports whether every dependency and worker is up
+func healthy(deps []Dependency, workers []Worker) bool {
+	for _, dep := range deps {
+		if !dep.OK {
+			return false
+		}
+	}
+	for _, worker := range workers {
+		if !worker.Running {
+			return false
+		}
+	}
+	return true
+}
+
+// Healthz answers as long as the process can serve requests
+func (c *Checker) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readyz answers 503 with the failures while a dependency is down or a
+// worker stopped, so the orchestrator stops routing traffic to the instance
+func (c *Checker) Readyz(w http.ResponseWriter, r *http.Request) {
+	deps, workers := c.run(r.Context())
+	if healthy(deps, workers) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		return
+	}
+
+	failures := make(map[string]string)
+	for _, dep := range deps {
+		if !dep.OK {
+			failures[dep.Name] = dep.Error
+		}
+	}
+	for _, worker := range workers {
+		if !worker.Running {
+			failures[worker.Name] = "not running"
+		}
+	}
+	writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+		"status":   "unavailable",
+		"failures": failures,
+	})
+}
+
+// Status reports the build, uptime, dependency latencies and workers. It
+// answers 200 even when degraded; readiness is the job of /readyz. Without a
+// status token the report is disabled and Status answers 404.
+func (c *Checker) Status(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	deps, workers := c.run(r.Context())
+	status := Status{
+		Status:       "ok",
+		Version:      c.version,
+		GoVersion:    runtime.Version(),
+		StartedAt:    c.started.UTC(),
+		Uptime:       time.Since(c.started).Round(time.Second).String(),
+		Dependencies: deps,
+		Workers:      workers,
+	}
+	if !healthy(deps, workers) {
+		status.Status = "degraded"
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+// buildVersion returns the VCS revision recorded in the binary, "dev" when
+// there is none
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+
+	var revision string
+	var modified bool
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision == "" {
+		return "dev"
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified {
+		revision += "-dirty"
+	}
+	return revision
+}
+
+// Protect guards an operator endpoint, such as /metrics, with the status
+// token, like /status
+func (c *Checker) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.authorize(w, r) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// authorize checks the status token of r, answering 404 while no token is
+// configured and 401 when r does not carry it
+func (c *Checker) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if c.statusToken == "" {
+		http.NotFound(w, r)
+		return false
+	}
+	token := r.Header.Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(token), []byte("Bearer "+c.statusToken)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
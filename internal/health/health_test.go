@@ -0,0 +1,131 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func serve(t *testing.T, handler http.HandlerFunc, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httptest.NewRequest("GET", "/", nil)
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	handler(w, r)
+	return w
+}
+
+func TestChecker_Readyz(t *testing.T) {
+	var dbErr error
+	c := New("test", time.Second, "")
+	c.AddCheck("database", func(ctx context.Context) error { return dbErr })
+	stopped := c.Worker("relay")
+
+	if w := serve(t, c.Readyz, nil); w.Code != http.StatusOK {
+		t.Errorf("Readyz() = %d, want 200", w.Code)
+	}
+
+	dbErr = errors.New("connection refused")
+	w := serve(t, c.Readyz, nil)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Readyz() with a failing check = %d, want 503", w.Code)
+	}
+	var body struct {
+		Failures map[string]string `json:"failures"`
+	}
+	json.NewDecoder(w.Body).Decode(&body)
+	if body.Failures["database"] != "connection refused" {
+		t.Errorf("failures = %v, want the database error", body.Failures)
+	}
+
+	dbErr = nil
+	stopped()
+	if w := serve(t, c.Readyz, nil); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Readyz() with a stopped worker = %d, want 503", w.Code)
+	}
+
+	// Liveness does not depend on anything
+	if w := serve(t, c.Healthz, nil); w.Code != http.StatusOK {
+		t.Errorf("Healthz() = %d, want 200", w.Code)
+	}
+}
+
+func TestChecker_timeout(t *testing.T) {
+	c := New("test", 10*time.Millisecond, "")
+	c.AddCheck("database", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	if w := serve(t, c.Readyz, nil); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Readyz() with a hanging check = %d, want 503", w.Code)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Readyz() took %s despite the timeout", elapsed)
+	}
+}
+
+func TestChecker_Status(t *testing.T) {
+	c := New("v1.2.3", time.Second, "secret")
+	c.AddCheck("database", func(ctx context.Context) error { return nil })
+	c.Worker("relay")
+
+	if w := serve(t, c.Status, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Status() without token = %d, want 401", w.Code)
+	}
+	if w := serve(t, New("v1.2.3", time.Second, "").Status, http.Header{"Authorization": {"Bearer "}}); w.Code != http.StatusNotFound {
+		t.Errorf("Status() without status token = %d, want 404", w.Code)
+	}
+
+	w := serve(t, c.Status, http.Header{"Authorization": {"Bearer secret"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status() = %d, want 200", w.Code)
+	}
+	var status Status
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != "ok" || status.Version != "v1.2.3" {
+		t.Errorf("status = %s %s, want ok v1.2.3", status.Status, status.Version)
+	}
+	if len(status.Dependencies) != 1 || !status.Dependencies[0].OK {
+		t.Errorf("dependencies = %+v", status.Dependencies)
+	}
+	if len(status.Workers) != 1 || !status.Workers[0].Running {
+		t.Errorf("workers = %+v", status.Workers)
+	}
+}
+
+func TestChecker_Protect(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name   string
+		token  string
+		header http.Header
+		want   int
+	}{
+		{name: "no status token", header: http.Header{"Authorization": {"Bearer "}}, want: http.StatusNotFound},
+		{name: "missing token", token: "secret", want: http.StatusUnauthorized},
+		{name: "wrong token", token: "secret", header: http.Header{"Authorization": {"Bearer other"}}, want: http.StatusUnauthorized},
+		{name: "valid token", token: "secret", header: http.Header{"Authorization": {"Bearer secret"}}, want: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New("test", time.Second, tt.token)
+			if w := serve(t, c.Protect(next).ServeHTTP, tt.header); w.Code != tt.want {
+				t.Errorf("Protect() = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
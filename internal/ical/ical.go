@@ -0,0 +1,173 @@
+// Package ical writes task due dates as RFC 5545 calendars.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"task-management/internal/entities"
+)
+
+// Component selects how tasks appear in calendars
+type Component string
+
+const (
+	// Event shows tasks as events, which every calendar client displays
+	Event Component = "VEVENT"
+
+	// Todo shows tasks as to-dos, for clients with task list support
+	Todo Component = "VTODO"
+)
+
+const (
+	productID = "-//task-management//Task calendar//EN"
+
+	// uidDomain scopes task UIDs so they never collide with other calendars
+	uidDomain = "task-management"
+
+	// maxLineOctets is the longest content line allowed before folding
+	maxLineOctets = 75
+
+	dateTimeFormat = "20060102T150405Z"
+	dateFormat     = "20060102"
+)
+
+// Writer encodes tasks as calendar components. Close must be called to end
+// the calendar.
+type Writer struct {
+	w         *bufio.Writer
+	component Component
+	now       time.Time
+	started   bool
+}
+
+// NewWriter returns a calendar writer emitting the given component
+func NewWriter(w io.Writer, component Component, name string) *Writer {
+	cw := &Writer{w: bufio.NewWriter(w), component: component, now: time.Now().UTC()}
+	cw.line("BEGIN", "VCALENDAR")
+	cw.line("VERSION", "2.0")
+	cw.line("PRODID", productID)
+	cw.line("CALSCALE", "GREGORIAN")
+	cw.line("METHOD", "PUBLISH")
+	if name != "" {
+		cw.line("X-WR-CALNAME", escape(name))
+	}
+	return cw
+}
+
+// UID returns the stable identifier of a task in calendars, so clients
+// update the entry when the task changes instead of adding a new one
+func UID(task *entities.TaskResponse) string {
+	return task.ID.String() + "@" + uidDomain
+}
+
+// Write adds a task to the calendar. Tasks without a due date are skipped.
+func (cw *Writer) Write(task *entities.TaskResponse) error {
+	if task.DueDate == nil {
+		return nil
+	}
+
+	component := string(cw.component)
+	cw.line("BEGIN", component)
+	cw.line("UID", UID(task))
+	cw.line("DTSTAMP", cw.now.Format(dateTimeFormat))
+	cw.line("CREATED", task.CreatedAt.UTC().Format(dateTimeFormat))
+	cw.line("LAST-MODIFIED", task.UpdatedAt.UTC().Format(dateTimeFormat))
+	// Any change to the task bumps its updated_at, which keeps the
+	// sequence increasing as RFC 5545 requires
+	cw.line("SEQUENCE", fmt.Sprint(task.UpdatedAt.Unix()))
+	cw.line("SUMMARY", escape(task.Title))
+	if task.Description != "" {
+		cw.line("DESCRIPTION", escape(task.Description))
+	}
+	if len(task.Labels) > 0 {
+		labels := make([]string, len(task.Labels))
+		for i, label := range task.Labels {
+			labels[i] = escape(label)
+		}
+		cw.line("CATEGORIES", strings.Join(labels, ","))
+	}
+
+	// Due dates at midnight UTC are plain dates and become all-day entries
+	due := task.DueDate.UTC()
+	dueName, dueValue := "DTSTART", due.Format(dateTimeFormat)
+	if cw.component == Todo {
+		dueName = "DUE"
+	}
+	if due.Equal(due.Truncate(24 * time.Hour)) {
+		dueName, dueValue = dueName+";VALUE=DATE", due.Format(dateFormat)
+	}
+	cw.line(dueName, dueValue)
+
+	cw.line("STATUS", status(cw.component, task.Status))
+	if cw.component == Todo && task.Status == entities.StatusCompleted {
+		cw.line("COMPLETED", task.UpdatedAt.UTC().Format(dateTimeFormat))
+	}
+
+	cw.line("END", component)
+	return nil
+}
+
+// Close ends the calendar and flushes it
+func (cw *Writer) Close() error {
+	cw.line("END", "VCALENDAR")
+	return cw.w.Flush()
+}
+
+// status maps a task status to the STATUS values allowed for the component
+func status(component Component, status entities.TaskStatus) string {
+	if component == Todo {
+		switch status {
+		case entities.StatusInProgress:
+			return "IN-PROCESS"
+		case entities.StatusCompleted:
+			return "COMPLETED"
+		case entities.StatusCancelled:
+			return "CANCELLED"
+		}
+		return "NEEDS-ACTION"
+	}
+
+	if status == entities.StatusCancelled {
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
+
+// line writes a content line, folding it so no line exceeds 75 octets
+// without splitting multi-byte characters
+func (cw *Writer) line(name, value string) {
+	content := name + ":" + value
+
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		cw.w.WriteString(content[:cut])
+		cw.w.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+
+	cw.w.WriteString(content)
+	cw.w.WriteString("\r\n")
+}
+
+// escape escapes a TEXT value
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(text)
+}
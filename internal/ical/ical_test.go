@@ -0,0 +1,112 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestWriter(t *testing.T) {
+	id := uuid.Must(uuid.FromString("6f1c0f3e-3a57-4d5e-9c4b-2f4e8f0a1b2c"))
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2026, 10, 2, 9, 30, 0, 0, time.UTC)
+	allDay := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	timed := time.Date(2026, 11, 2, 14, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	tasks := []*entities.TaskResponse{
+		{
+			ID:          id,
+			Title:       "Fix login bug; urgent, really",
+			Description: "Users cannot log in\nafter the release",
+			Status:      entities.StatusCompleted,
+			DueDate:     &allDay,
+			CreatedAt:   created,
+			UpdatedAt:   updated,
+			Labels:      []string{"backend", "bug"},
+		},
+		{
+			ID:        id,
+			Title:     "Ship",
+			Status:    entities.StatusInProgress,
+			DueDate:   &timed,
+			CreatedAt: created,
+			UpdatedAt: updated,
+		},
+		{ID: id, Title: "No due date", CreatedAt: created, UpdatedAt: updated},
+	}
+
+	var b bytes.Buffer
+	w := NewWriter(&b, Todo, "Tasks")
+	w.now = updated
+	for _, task := range tasks {
+		w.Write(task)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//task-management//Task calendar//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Tasks",
+		"BEGIN:VTODO",
+		"UID:6f1c0f3e-3a57-4d5e-9c4b-2f4e8f0a1b2c@task-management",
+		"DTSTAMP:20261002T093000Z",
+		"CREATED:20261001T090000Z",
+		"LAST-MODIFIED:20261002T093000Z",
+		"SEQUENCE:1790933400",
+		`SUMMARY:Fix login bug\; urgent\, really`,
+		`DESCRIPTION:Users cannot log in\nafter the release`,
+		"CATEGORIES:backend,bug",
+		"DUE;VALUE=DATE:20261101",
+		"STATUS:COMPLETED",
+		"COMPLETED:20261002T093000Z",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:6f1c0f3e-3a57-4d5e-9c4b-2f4e8f0a1b2c@task-management",
+		"DTSTAMP:20261002T093000Z",
+		"CREATED:20261001T090000Z",
+		"LAST-MODIFIED:20261002T093000Z",
+		"SEQUENCE:1790933400",
+		"SUMMARY:Ship",
+		"DUE:20261102T130000Z",
+		"STATUS:IN-PROCESS",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	if got := b.String(); got != want {
+		t.Errorf("Writer output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriter_folding(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, Event, "")
+	w.line("SUMMARY", strings.Repeat("é", 60))
+	w.Close()
+
+	for _, line := range strings.Split(b.String(), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %q is %d octets long", line, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %q splits a character", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(b.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("é", 60)+"\r\n") {
+		t.Errorf("unfolded output lost content: %q", unfolded)
+	}
+}
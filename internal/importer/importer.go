@@ -0,0 +1,248 @@
+// Package importer reads tasks from CSV and JSON files and validates them
+// before they are handed to the service layer.
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"task-management/internal/entities"
+	"task-management/internal/services"
+
+	"github.com/go-playground/validator"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// labelSeparator splits the labels column of CSV files
+const labelSeparator = ";"
+
+// ErrInvalidFile is returned when the file cannot be read at all, as opposed
+// to individual rows being invalid
+var ErrInvalidFile = errors.New("invalid import file")
+
+// Options describes an import file and how to handle it
+type Options struct {
+	Format  string
+	Mapping string
+	DryRun  bool
+}
+
+// Run parses and validates an import file and hands it to the service when
+// every row is valid. Invalid rows are reported in the result and nothing is
+// imported; an error is only returned when the file itself is unreadable or
+// the import fails.
+func Run(ctx context.Context, s services.Service, v *validator.Validate, r io.Reader, opts Options) (*entities.ImportResult, error) {
+	mapping, err := ParseMapping(opts.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, rowErrors, err := Parse(r, opts.Format, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(rows) + len(rowErrors)
+	rowErrors = append(rowErrors, Validate(v, rows)...)
+	if len(rowErrors) > 0 {
+		sort.SliceStable(rowErrors, func(i, j int) bool { return rowErrors[i].Row < rowErrors[j].Row })
+		return &entities.ImportResult{DryRun: opts.DryRun, Total: total, Errors: rowErrors}, nil
+	}
+
+	return s.ImportTasks(ctx, rows, opts.DryRun)
+}
+
+// columns are the task fields a CSV column can be mapped to
+var columns = []string{"title", "description", "status", "due_date", "labels"}
+
+// ParseMapping parses a column mapping such as "title=Name,due_date=Due by"
+// into task field to CSV header pairs. Fields that are not mapped are read
+// from the column with the same name.
+func ParseMapping(raw string) (map[string]string, error) {
+	mapping := make(map[string]string, len(columns))
+	for _, column := range columns {
+		mapping[column] = column
+	}
+	if strings.TrimSpace(raw) == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		field, header, ok := strings.Cut(pair, "=")
+		field = strings.TrimSpace(field)
+		if !ok || strings.TrimSpace(header) == "" {
+			return nil, fmt.Errorf("%w: mapping %q must have the form field=header", ErrInvalidFile, pair)
+		}
+		if _, known := mapping[field]; !known {
+			return nil, fmt.Errorf("%w: unknown field %q in mapping, expected one of %s", ErrInvalidFile, field, strings.Join(columns, ", "))
+		}
+		mapping[field] = strings.TrimSpace(header)
+	}
+
+	return mapping, nil
+}
+
+// Parse reads tasks in the given format. Rows that cannot be converted into
+// a task request are reported as row errors; the other rows are returned.
+func Parse(r io.Reader, format string, mapping map[string]string) ([]entities.ImportRow, []entities.ImportRowError, error) {
+	switch format {
+	case FormatJSON:
+		rows, err := parseJSON(r)
+		return rows, nil, err
+	case FormatCSV:
+		return parseCSV(r, mapping)
+	}
+	return nil, nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidFile, format)
+}
+
+// parseJSON reads a JSON array of task requests
+func parseJSON(r io.Reader) ([]entities.ImportRow, error) {
+	var tasks []entities.TaskRequest
+	if err := json.NewDecoder(r).Decode(&tasks); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	rows := make([]entities.ImportRow, len(tasks))
+	for i, task := range tasks {
+		rows[i] = entities.ImportRow{Row: i + 1, Task: task}
+	}
+
+	return rows, nil
+}
+
+// parseCSV reads a CSV file whose first line holds the column headers
+func parseCSV(r io.Reader, mapping map[string]string) ([]entities.ImportRow, []entities.ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: reading header: %v", ErrInvalidFile, err)
+	}
+
+	// Spreadsheet exports often start with a byte order mark
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	if _, ok := index[mapping["title"]]; !ok {
+		return nil, nil, fmt.Errorf("%w: missing title column %q", ErrInvalidFile, mapping["title"])
+	}
+
+	var rows []entities.ImportRow
+	var rowErrors []entities.ImportRowError
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+
+		value := func(field string) string {
+			if i, ok := index[mapping[field]]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		task := entities.TaskRequest{
+			Title:       value("title"),
+			Description: value("description"),
+			Status:      entities.TaskStatus(value("status")),
+		}
+		if task.Status == "" {
+			task.Status = entities.StatusPending
+		}
+		for _, label := range strings.Split(value("labels"), labelSeparator) {
+			if label = strings.TrimSpace(label); label != "" {
+				task.Labels = append(task.Labels, label)
+			}
+		}
+
+		if due := value("due_date"); due != "" {
+			dueDate, err := parseDate(due)
+			if err != nil {
+				rowErrors = append(rowErrors, entities.ImportRowError{Row: row, Field: "due_date", Error: err.Error()})
+				continue
+			}
+			task.DueDate = &dueDate
+		}
+
+		rows = append(rows, entities.ImportRow{Row: row, Task: task})
+	}
+
+	return rows, rowErrors, nil
+}
+
+// parseDate accepts RFC 3339 timestamps and plain dates
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected 2006-01-02 or RFC 3339", value)
+	}
+	return t, nil
+}
+
+// Validate checks every row with the validator used by the task endpoints
+// and reports one error per invalid field
+func Validate(v *validator.Validate, rows []entities.ImportRow) []entities.ImportRowError {
+	var rowErrors []entities.ImportRowError
+	for _, row := range rows {
+		if err := v.Struct(row.Task); err != nil {
+			var fieldErrors validator.ValidationErrors
+			if !errors.As(err, &fieldErrors) {
+				rowErrors = append(rowErrors, entities.ImportRowError{Row: row.Row, Error: err.Error()})
+				continue
+			}
+			for _, fe := range fieldErrors {
+				rowErrors = append(rowErrors, entities.ImportRowError{
+					Row:   row.Row,
+					Field: jsonName(fe.StructField()),
+					Error: fmt.Sprintf("failed on the %q rule", fe.Tag()),
+				})
+			}
+			continue
+		}
+
+		if !row.Task.Status.Valid() {
+			rowErrors = append(rowErrors, entities.ImportRowError{
+				Row:   row.Row,
+				Field: "status",
+				Error: fmt.Sprintf("unknown status %q", row.Task.Status),
+			})
+		}
+	}
+
+	return rowErrors
+}
+
+// jsonName returns the JSON name of a TaskRequest field
+func jsonName(structField string) string {
+	// Nested errors such as Labels[0] refer to their parent field
+	structField, _, _ = strings.Cut(structField, "[")
+
+	field, ok := reflect.TypeOf(entities.TaskRequest{}).FieldByName(structField)
+	if !ok {
+		return structField
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
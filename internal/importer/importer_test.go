@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"task-management/internal/entities"
+
+	"github.com/go-playground/validator"
+)
+
+func TestParse_csv(t *testing.T) {
+	mapping, err := ParseMapping("title=Name, due_date=Due")
+	if err != nil {
+		t.Fatalf("ParseMapping() error = %v", err)
+	}
+
+	input := "\ufeffName,description,status,Due,labels\n" +
+		"Fix login bug,Users cannot log in,InProgress,2026-11-01,backend; bug\n" +
+		"Write docs,,,,\n" +
+		"Ship it,,Pending,next week,\n"
+
+	rows, rowErrors, err := Parse(strings.NewReader(input), FormatCSV, mapping)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	want := []entities.ImportRow{
+		{Row: 1, Task: entities.TaskRequest{
+			Title:       "Fix login bug",
+			Description: "Users cannot log in",
+			Status:      entities.StatusInProgress,
+			DueDate:     &due,
+			Labels:      []string{"backend", "bug"},
+		}},
+		{Row: 2, Task: entities.TaskRequest{Title: "Write docs", Status: entities.StatusPending}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Parse() rows = %+v, want %+v", rows, want)
+	}
+
+	wantErrors := []entities.ImportRowError{
+		{Row: 3, Field: "due_date", Error: `invalid date "next week", expected 2006-01-02 or RFC 3339`},
+	}
+	if !reflect.DeepEqual(rowErrors, wantErrors) {
+		t.Errorf("Parse() row errors = %+v, want %+v", rowErrors, wantErrors)
+	}
+}
+
+func TestParse_json(t *testing.T) {
+	input := `[{"title": "Fix login bug", "status": "Pending", "labels": ["backend"]}, {"status": "Done"}]`
+
+	rows, _, err := Parse(strings.NewReader(input), FormatJSON, nil)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0].Task.Title != "Fix login bug" || rows[1].Row != 2 {
+		t.Errorf("Parse() rows = %+v", rows)
+	}
+
+	got := Validate(validator.New(), rows)
+	want := []entities.ImportRowError{
+		{Row: 2, Field: "title", Error: `failed on the "required" rule`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %+v, want %+v", got, want)
+	}
+
+	rows[1].Task.Title = "Write docs"
+	got = Validate(validator.New(), rows)
+	want = []entities.ImportRowError{
+		{Row: 2, Field: "status", Error: `unknown status "Done"`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParse_invalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		format  string
+		mapping string
+	}{
+		{name: "malformed json", input: `{"title": "x"}`, format: FormatJSON},
+		{name: "missing title column", input: "name\nx\n", format: FormatCSV},
+		{name: "unknown mapping field", input: "title\nx\n", format: FormatCSV, mapping: "owner=Owner"},
+		{name: "malformed mapping", input: "title\nx\n", format: FormatCSV, mapping: "title"},
+		{name: "unsupported format", input: "", format: "xlsx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := ParseMapping(tt.mapping)
+			if err == nil {
+				_, _, err = Parse(strings.NewReader(tt.input), tt.format, mapping)
+			}
+			if !errors.Is(err, ErrInvalidFile) {
+				t.Errorf("Parse() error = %v, want ErrInvalidFile", err)
+			}
+		})
+	}
+}
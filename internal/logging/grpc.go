@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadata is the gRPC counterpart of RequestIDHeader
+const requestIDMetadata = "x-request-id"
+
+// UnaryServerInterceptor gives each gRPC call an ID and a logger carrying
+// it, like Middleware does for HTTP requests, then writes an access log
+// once the call returns. The ID is taken from the x-request-id metadata when
+// the caller sent a usable one, and returned in the response headers.
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, reqLogger := withCall(ctx, logger)
+		// The header is sent with the response, an error only means the
+		// call already ended
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, RequestID(ctx)))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, reqLogger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls.
+// The access log is written when the stream ends.
+func StreamServerInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, reqLogger := withCall(ss.Context(), logger)
+		_ = ss.SetHeader(metadata.Pairs(requestIDMetadata, RequestID(ctx)))
+
+		start := time.Now()
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, reqLogger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func withCall(ctx context.Context, logger *slog.Logger) (context.Context, *slog.Logger) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadata); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	return withRequest(ctx, logger, requestID(id))
+}
+
+// logCall writes the access log of a call, at error level when it failed on
+// the server side
+func logCall(ctx context.Context, logger *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		level = slog.LevelError
+	}
+	logger.LogAttrs(ctx, level, "rpc",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+	)
+}
+
+// contextStream overrides the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
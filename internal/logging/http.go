@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"task-management/internal/web/httpx"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the ID of a request, accepted from the caller or
+// generated, and echoed in the response
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// RequestID returns the ID of the request being served, empty outside of
+// one
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// quietRoutes are polled by the infrastructure, their access logs are only
+// written at debug level
+var quietRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Middleware gives each request matched by the router an ID and a logger
+// carrying it, then writes an access log once the request is served. The ID
+// is taken from X-Request-ID when the caller sent a usable one.
+func Middleware(logger *slog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := requestID(r.Header.Get(RequestIDHeader))
+			w.Header().Set(RequestIDHeader, id)
+
+			route := httpx.Route(r)
+			ctx, reqLogger := withRequest(r.Context(), logger, id)
+
+			start := time.Now()
+			rec := httpx.NewRecorder(w)
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			level := slog.LevelInfo
+			switch {
+			case rec.Status() >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case quietRoutes[route]:
+				level = slog.LevelDebug
+			}
+			reqLogger.LogAttrs(ctx, level, "request",
+				slog.String("method", r.Method),
+				slog.String("route", route),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rec.Status()),
+				slog.Int64("bytes", rec.Bytes()),
+				slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+				slog.String("remote_addr", r.RemoteAddr),
+				slog.String("user_agent", r.UserAgent()),
+			)
+		})
+	}
+}
+
+// requestID returns the ID sent by the caller when it is usable, a new one
+// otherwise
+func requestID(id string) string {
+	if !validRequestID(id) {
+		return uuid.Must(uuid.NewV4()).String()
+	}
+	return id
+}
+
+// withRequest returns ctx carrying the request ID and a logger derived from
+// logger, tagged with the ID and the trace of the request, along with that
+// logger
+func withRequest(ctx context.Context, logger *slog.Logger, id string) (context.Context, *slog.Logger) {
+	reqLogger := logger.With("request_id", id)
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		reqLogger = reqLogger.With("trace_id", span.TraceID().String())
+	}
+
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return WithLogger(ctx, reqLogger), reqLogger
+}
+
+// validRequestID reports whether a caller's request ID can be logged as is:
+// at most 128 printable ASCII characters
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
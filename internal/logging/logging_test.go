@@ -0,0 +1,214 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"task-management/internal/config"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// lines decodes the JSON log lines written to buf
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(config.Log{Level: "info"}, &buf)
+
+	router := mux.NewRouter()
+	router.Use(Middleware(logger))
+	router.HandleFunc("/api/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info("task loaded")
+		w.Write([]byte("{}"))
+	})
+	router.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+
+	t.Run("accepts the caller's ID", func(t *testing.T) {
+		buf.Reset()
+		r := httptest.NewRequest("GET", "/api/tasks/1", nil)
+		r.Header.Set(RequestIDHeader, "req-42")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if got := w.Header().Get(RequestIDHeader); got != "req-42" {
+			t.Errorf("response ID = %q, want req-42", got)
+		}
+		entries := lines(t, &buf)
+		if len(entries) != 2 {
+			t.Fatalf("log lines = %d, want the handler's and the access log", len(entries))
+		}
+		for _, entry := range entries {
+			if entry["request_id"] != "req-42" {
+				t.Errorf("line %v does not carry the request ID", entry)
+			}
+		}
+		access := entries[1]
+		if access["msg"] != "request" || access["route"] != "/api/tasks/{id}" || access["status"] != float64(200) || access["bytes"] != float64(2) {
+			t.Errorf("access log = %v", access)
+		}
+	})
+
+	t.Run("replaces unusable IDs", func(t *testing.T) {
+		for _, id := range []string{"", "has space", strings.Repeat("x", 129)} {
+			r := httptest.NewRequest("GET", "/api/tasks/1", nil)
+			r.Header.Set(RequestIDHeader, id)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if got := w.Header().Get(RequestIDHeader); got == id || len(got) != 36 {
+				t.Errorf("ID %q answered with %q, want a generated UUID", id, got)
+			}
+		}
+	})
+
+	t.Run("probes at debug", func(t *testing.T) {
+		buf.Reset()
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthz", nil))
+		if buf.Len() != 0 {
+			t.Errorf("probe logged at info: %s", buf.String())
+		}
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	interceptor := UnaryServerInterceptor(New(config.Log{Level: "info"}, &buf))
+	info := &grpc.UnaryServerInfo{FullMethod: "/task.v1.TaskService/GetTask"}
+
+	t.Run("accepts the caller's ID", func(t *testing.T) {
+		buf.Reset()
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-42"))
+		var handlerID string
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handlerID = RequestID(ctx)
+			FromContext(ctx).Info("task loaded")
+			return nil, status.Error(codes.NotFound, "task not found")
+		})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("interceptor() error = %v, want the handler's", err)
+		}
+		if handlerID != "req-42" {
+			t.Errorf("RequestID() in the handler = %q, want req-42", handlerID)
+		}
+
+		entries := lines(t, &buf)
+		if len(entries) != 2 {
+			t.Fatalf("log lines = %d, want the handler's and the access log", len(entries))
+		}
+		for _, entry := range entries {
+			if entry["request_id"] != "req-42" {
+				t.Errorf("line %v does not carry the request ID", entry)
+			}
+		}
+		access := entries[1]
+		if access["msg"] != "rpc" || access["method"] != info.FullMethod || access["code"] != "NotFound" || access["level"] != "INFO" {
+			t.Errorf("access log = %v", access)
+		}
+	})
+
+	t.Run("generates an ID and logs failures at error", func(t *testing.T) {
+		buf.Reset()
+		var handlerID string
+		interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handlerID = RequestID(ctx)
+			return nil, errors.New("boom")
+		})
+		if len(handlerID) != 36 {
+			t.Errorf("RequestID() in the handler = %q, want a generated UUID", handlerID)
+		}
+
+		entries := lines(t, &buf)
+		if len(entries) != 1 || entries[0]["level"] != "ERROR" || entries[0]["code"] != "Unknown" {
+			t.Errorf("access log = %v", entries)
+		}
+	})
+}
+
+func TestGormLogger(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := WithLogger(context.Background(), New(config.Log{Level: "debug"}, &buf).With("request_id", "req-42"))
+	query := func() (string, int64) { return `SELECT * FROM "tasks"`, 3 }
+
+	tests := []struct {
+		name    string
+		level   string
+		elapsed time.Duration
+		err     error
+		want    string
+	}{
+		{"fast query at warn", "warn", time.Millisecond, nil, ""},
+		{"not found at warn", "warn", time.Millisecond, gorm.ErrRecordNotFound, ""},
+		{"slow query at warn", "warn", time.Second, nil, "slow query"},
+		{"failed query at warn", "warn", time.Millisecond, errors.New("connection reset"), "query failed"},
+		{"slow query at error", "error", time.Second, nil, ""},
+		{"failed query at silent", "silent", time.Millisecond, errors.New("connection reset"), ""},
+		{"fast query at debug", "debug", time.Millisecond, nil, "query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			l := NewGormLogger(config.Log{QueryLevel: tt.level, SlowQueryThreshold: 100 * time.Millisecond})
+			l.Trace(ctx, time.Now().Add(-tt.elapsed), query, tt.err)
+
+			entries := lines(t, &buf)
+			if tt.want == "" {
+				if len(entries) != 0 {
+					t.Errorf("logged %v, want nothing", entries)
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("log lines = %d, want 1", len(entries))
+			}
+			entry := entries[0]
+			if entry["msg"] != tt.want || entry["request_id"] != "req-42" || entry["rows"] != float64(3) {
+				t.Errorf("logged %v, want %q with the request ID", entry, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormLogger_ParamsFilter(t *testing.T) {
+	sql := `SELECT * FROM "tasks" WHERE title = $1`
+	for level, want := range map[string]int{"warn": 0, "debug": 1} {
+		l := NewGormLogger(config.Log{QueryLevel: level})
+		if _, params := l.ParamsFilter(context.Background(), sql, "secret"); len(params) != want {
+			t.Errorf("%s: params = %v, want %d", level, params, want)
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("FromContext() without a logger is not the default logger")
+	}
+}
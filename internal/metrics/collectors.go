@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"task-management/internal/entities"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// collectTimeout bounds the queries run when Prometheus scrapes
+const collectTimeout = 5 * time.Second
+
+// RegisterDB exports the connection pool statistics of db as go_sql_*
+// metrics labeled with db_name
+func RegisterDB(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// TaskCounter counts the tasks in each status. It is satisfied by
+// task.Task.
+type TaskCounter interface {
+	CountByStatus(ctx context.Context) (map[entities.TaskStatus]int64, error)
+}
+
+// RegisterTasks exports the number of tasks in each status, counted when
+// Prometheus scrapes
+func RegisterTasks(tasks TaskCounter) error {
+	return prometheus.Register(&taskCollector{
+		tasks: tasks,
+		desc:  prometheus.NewDesc("tasks", "Tasks by status.", []string{"status"}, nil),
+	})
+}
+
+type taskCollector struct {
+	tasks TaskCounter
+	desc  *prometheus.Desc
+}
+
+// taskStatuses are always reported, so empty statuses show up as zero
+var taskStatuses = []entities.TaskStatus{
+	entities.StatusPending,
+	entities.StatusInProgress,
+	entities.StatusCompleted,
+	entities.StatusCancelled,
+}
+
+func (c *taskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *taskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	counts, err := c.tasks.CountByStatus(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	for _, status := range taskStatuses {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(counts[status]), string(status))
+	}
+}
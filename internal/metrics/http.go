@@ -0,0 +1,61 @@
+// Package metrics exposes Prometheus metrics for the HTTP server, the
+// database and the tasks themselves. They are registered with the default
+// registry, served by promhttp.Handler.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-management/internal/web/httpx"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of HTTP requests by method, route template and status code, event streams and WebSockets excluded.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	requestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests being served by route template, open event streams and WebSockets included.",
+	}, []string{"route"})
+)
+
+// Middleware records the requests matched by the router. Routes are labeled
+// by their template, e.g. /api/tasks/{id}, to keep the number of series
+// bounded.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := httpx.Route(r)
+
+		inFlight := requestsInFlight.WithLabelValues(route)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		rec := httpx.NewRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		status := strconv.Itoa(rec.Status())
+		requestsTotal.WithLabelValues(r.Method, route, status).Inc()
+
+		// Streams last as long as the client stays, which says nothing about
+		// latency
+		if rec.Hijacked() || strings.HasPrefix(rec.Header().Get("Content-Type"), "text/event-stream") {
+			return
+		}
+		requestDuration.WithLabelValues(r.Method, route, status).Observe(time.Since(start).Seconds())
+	})
+}
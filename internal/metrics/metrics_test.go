@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"task-management/internal/entities"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Middleware)
+	router.HandleFunc("/api/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "task not found", http.StatusNotFound)
+	})
+	router.HandleFunc("/api/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+	})
+
+	for _, path := range []string{"/api/tasks/1", "/api/tasks/2", "/api/events"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", "/api/tasks/{id}", "404")); got != 2 {
+		t.Errorf("requests to /api/tasks/{id} = %v, want 2", got)
+	}
+	if got := testutil.CollectAndCount(requestDuration, "http_request_duration_seconds"); got != 1 {
+		t.Errorf("duration series = %d, want only the task route's", got)
+	}
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues("GET", "/api/events", "200")); got != 1 {
+		t.Errorf("requests to /api/events = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(requestsInFlight.WithLabelValues("/api/tasks/{id}")); got != 0 {
+		t.Errorf("in flight = %v, want 0 once served", got)
+	}
+}
+
+func TestGormPlugin(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "tasks"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "tasks"`).WillReturnError(errors.New("connection reset"))
+
+	var tasks []entities.Task
+	db.Find(&tasks)
+	db.Find(&tasks)
+
+	for _, status := range []string{"ok", "error"} {
+		h := queryDuration.WithLabelValues("query", "tasks", status).(prometheus.Histogram)
+		if got := testutil.CollectAndCount(h); got != 1 {
+			t.Errorf("%s query series = %d, want 1", status, got)
+		}
+	}
+}
+
+type taskCounter map[entities.TaskStatus]int64
+
+func (c taskCounter) CountByStatus(ctx context.Context) (map[entities.TaskStatus]int64, error) {
+	return c, nil
+}
+
+func TestTaskCollector(t *testing.T) {
+	c := &taskCollector{
+		tasks: taskCounter{entities.StatusPending: 3, entities.StatusCompleted: 1},
+		desc:  prometheus.NewDesc("tasks", "Tasks by status.", []string{"status"}, nil),
+	}
+
+	want := `
+# HELP tasks Tasks by status.
+# TYPE tasks gauge
+tasks{status="Cancelled"} 0
+tasks{status="Completed"} 1
+tasks{status="InProgress"} 0
+tasks{status="Pending"} 3
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
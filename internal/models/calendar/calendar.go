@@ -0,0 +1,83 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// Calendar interface defines methods for calendar token data operations
+type Calendar interface {
+	Create(ctx context.Context, token *entities.CalendarToken) error
+	GetByHash(ctx context.Context, hash string) (*entities.CalendarToken, error)
+	GetByUser(ctx context.Context, userID uuid.UUID) ([]entities.CalendarToken, error)
+	Touch(ctx context.Context, id uuid.UUID, at time.Time) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type calendarModel struct {
+	db *gorm.DB
+}
+
+// New creates a new instance of Calendar
+func New(db *gorm.DB) Calendar {
+	return &calendarModel{db: db}
+}
+
+// Create adds a new calendar token to the database
+func (m *calendarModel) Create(ctx context.Context, token *entities.CalendarToken) error {
+	// Generate a new UUID if not provided
+	if token.ID == uuid.Nil {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		token.ID = id
+	}
+
+	return m.db.WithContext(ctx).Create(token).Error
+}
+
+// GetByHash retrieves a calendar token by the hash of its secret
+func (m *calendarModel) GetByHash(ctx context.Context, hash string) (*entities.CalendarToken, error) {
+	var token entities.CalendarToken
+	result := m.db.WithContext(ctx).First(&token, "token_hash = ?", hash)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &token, nil
+}
+
+// GetByUser retrieves the calendar tokens of a user
+func (m *calendarModel) GetByUser(ctx context.Context, userID uuid.UUID) ([]entities.CalendarToken, error) {
+	var tokens []entities.CalendarToken
+	result := m.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Order("id").Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
+
+// Touch records when a calendar token was last used
+func (m *calendarModel) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return m.db.WithContext(ctx).Model(&entities.CalendarToken{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
+
+// Delete removes a calendar token owned by the given user
+func (m *calendarModel) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	result := m.db.WithContext(ctx).Delete(&entities.CalendarToken{}, "id = ? AND user_id = ?", id, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrCalendarTokenNotFound
+	}
+
+	return nil
+}
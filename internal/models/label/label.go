@@ -0,0 +1,106 @@
+package label
+
+import (
+	"context"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Label interface defines methods for label data operations
+type Label interface {
+	FindOrCreate(ctx context.Context, names []string) ([]entities.Label, error)
+	GetByTaskIDs(ctx context.Context, taskIDs []uuid.UUID) (map[uuid.UUID][]entities.Label, error)
+}
+
+type labelModel struct {
+	db *gorm.DB
+}
+
+// New creates a new instance of Label
+func New(db *gorm.DB) Label {
+	return &labelModel{db: db}
+}
+
+// FindOrCreate returns the labels with the given names, creating the ones
+// that do not exist yet. Names are normalized and deduplicated first.
+func (m *labelModel) FindOrCreate(ctx context.Context, names []string) ([]entities.Label, error) {
+	seen := make(map[string]bool, len(names))
+	labels := make([]entities.Label, 0, len(names))
+	for _, name := range names {
+		name = entities.NormalizeLabel(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, entities.Label{ID: id, Name: name})
+	}
+
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	db := m.db.WithContext(ctx)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Create(&labels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Labels that already existed keep their original id, so read them back
+	var existing []entities.Label
+	result := db.Where("name IN ?", keys(seen)).Order("name").Find(&existing)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return existing, nil
+}
+
+// GetByTaskIDs retrieves the labels of several tasks in a single query,
+// grouped by task and sorted by name
+func (m *labelModel) GetByTaskIDs(ctx context.Context, taskIDs []uuid.UUID) (map[uuid.UUID][]entities.Label, error) {
+	labels := make(map[uuid.UUID][]entities.Label, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return labels, nil
+	}
+
+	var rows []struct {
+		TaskID uuid.UUID
+		entities.Label
+	}
+	result := m.db.WithContext(ctx).
+		Table("labels").
+		Select("task_labels.task_id, labels.*").
+		Joins("JOIN task_labels ON task_labels.label_id = labels.id").
+		Where("task_labels.task_id IN ?", taskIDs).
+		Order("labels.name").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		labels[row.TaskID] = append(labels[row.TaskID], row.Label)
+	}
+
+	return labels, nil
+}
+
+func keys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	return out
+}
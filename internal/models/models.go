@@ -1,18 +1,60 @@
 package models
 
 import (
+	"context"
+
+	"task-management/internal/models/calendar"
+	"task-management/internal/models/label"
+	"task-management/internal/models/outbox"
 	"task-management/internal/models/task"
+	"task-management/internal/models/view"
+	"task-management/internal/models/webhook"
 
 	"gorm.io/gorm"
 )
 
 type Model struct {
-	Task task.Task
+	Task     task.Task
+	Label    label.Label
+	View     view.View
+	Calendar calendar.Calendar
+	Webhook  webhook.Webhook
+	Outbox   outbox.Outbox
+
+	db   *gorm.DB
+	bind func(tx *gorm.DB) *Model
 }
 
 // New creates a new instance of Model
 func New(gdb *gorm.DB) *Model {
 	return &Model{
-		Task: task.New(gdb),
+		Task:     task.New(gdb),
+		Label:    label.New(gdb),
+		View:     view.New(gdb),
+		Calendar: calendar.New(gdb),
+		Webhook:  webhook.New(gdb),
+		Outbox:   outbox.New(gdb),
+		db:       gdb,
+		bind:     New,
 	}
 }
+
+// WithDB returns a copy of m running its transactions on gdb. The models of
+// m are used as they are within the transactions, so they must not depend on
+// the connection, as is the case for mocks.
+func (m Model) WithDB(gdb *gorm.DB) *Model {
+	m.db = gdb
+	m.bind = func(*gorm.DB) *Model {
+		bound := m
+		return &bound
+	}
+	return &m
+}
+
+// Transaction runs fn with every model bound to the same database
+// transaction. The transaction is rolled back if fn returns an error.
+func (m *Model) Transaction(ctx context.Context, fn func(tx *Model) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(m.bind(tx))
+	})
+}
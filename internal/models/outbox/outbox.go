@@ -0,0 +1,171 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotifyChannel is the Postgres channel on which every outbox event is
+// announced once its transaction commits. The notification holds the event
+// id followed by a space and the payload, or only the id when the payload
+// does not fit in a notification.
+const NotifyChannel = "task_events"
+
+// maxNotifyPayload keeps notifications below the 8000 bytes Postgres accepts
+const maxNotifyPayload = 7900
+
+// Outbox interface defines methods for outbox event data operations
+type Outbox interface {
+	Add(ctx context.Context, events []entities.OutboxEvent) error
+	GetByIDs(ctx context.Context, ids []int64) ([]entities.OutboxEvent, error)
+	Relay(ctx context.Context, consumer string, limit int, lease time.Duration, fn func(events []entities.OutboxEvent) error) (int, error)
+	Prune(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxModel struct {
+	db *gorm.DB
+}
+
+// New creates a new instance of Outbox
+func New(db *gorm.DB) Outbox {
+	return &outboxModel{db: db}
+}
+
+// Add saves events to the outbox and announces them on NotifyChannel.
+// Postgres delivers the notifications when the transaction commits, in
+// commit order, and drops them if it rolls back.
+func (m *outboxModel) Add(ctx context.Context, events []entities.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	db := m.db.WithContext(ctx)
+	if err := db.CreateInBatches(events, 500).Error; err != nil {
+		return err
+	}
+
+	ids := make([]int64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+
+	return db.Exec(
+		"SELECT pg_notify(?, CASE WHEN octet_length(payload) <= ? THEN id::text || ' ' || payload ELSE id::text END) "+
+			"FROM outbox_events WHERE id IN ? ORDER BY id",
+		NotifyChannel, maxNotifyPayload, ids,
+	).Error
+}
+
+// GetByIDs retrieves outbox events by their IDs, ordered by ID
+func (m *outboxModel) GetByIDs(ctx context.Context, ids []int64) ([]entities.OutboxEvent, error) {
+	var events []entities.OutboxEvent
+	result := m.db.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return events, nil
+}
+
+// Relay passes up to limit events not yet delivered to consumer to fn, oldest
+// first, and records them as delivered once fn succeeds. The consumer is
+// claimed for lease beforehand, so only one relay publishes to it at a time
+// while no transaction stays open during fn. A relay finding the consumer
+// claimed returns without doing anything. Should a relay stop before
+// recording a batch, its claim expires and the batch is published again.
+func (m *outboxModel) Relay(ctx context.Context, consumer string, limit int, lease time.Duration, fn func(events []entities.OutboxEvent) error) (int, error) {
+	db := m.db.WithContext(ctx)
+
+	claim := uuid.Must(uuid.NewV4())
+	claimed, err := m.claim(ctx, consumer, claim, lease)
+	if err != nil || !claimed {
+		return 0, err
+	}
+
+	var events []entities.OutboxEvent
+	err = db.
+		Where("NOT EXISTS (SELECT 1 FROM outbox_deliveries d WHERE d.event_id = outbox_events.id AND d.consumer = ?)", consumer).
+		Order("id").
+		Limit(limit).
+		Find(&events).Error
+	if err == nil && len(events) > 0 {
+		err = fn(events)
+	}
+	if err != nil || len(events) == 0 {
+		// The claim expires anyway should releasing it fail
+		m.release(db, consumer, claim)
+		return 0, err
+	}
+
+	now := time.Now()
+	deliveries := make([]entities.OutboxDelivery, len(events))
+	for i, event := range events {
+		deliveries[i] = entities.OutboxDelivery{Consumer: consumer, EventID: event.ID, DeliveredAt: now}
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Event").Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error; err != nil {
+			return err
+		}
+		return m.release(tx, consumer, claim)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+// claim registers consumer and claims it for lease unless another relay holds
+// an unexpired claim on it
+func (m *outboxModel) claim(ctx context.Context, consumer string, claim uuid.UUID, lease time.Duration) (bool, error) {
+	db := m.db.WithContext(ctx)
+
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"last_seen_at": gorm.Expr("now()")}),
+	}).Create(&entities.OutboxConsumer{Name: consumer, LastSeenAt: time.Now()}).Error
+	if err != nil {
+		return false, err
+	}
+
+	result := db.Model(&entities.OutboxConsumer{}).
+		Where("name = ? AND (claimed_until IS NULL OR claimed_until < now())", consumer).
+		Updates(map[string]interface{}{
+			"claim":         claim,
+			"claimed_until": gorm.Expr("now() + make_interval(secs => ?)", lease.Seconds()),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// release gives up a claim on consumer, unless it expired and was taken over
+func (m *outboxModel) release(db *gorm.DB, consumer string, claim uuid.UUID) error {
+	return db.Model(&entities.OutboxConsumer{}).
+		Where("name = ? AND claim = ?", consumer, claim).
+		Updates(map[string]interface{}{"claim": nil, "claimed_until": nil}).Error
+}
+
+// Prune removes the events created before the given time that were delivered
+// to every consumer seen since then. Consumers no relay published to since
+// are considered gone and do not hold events back.
+func (m *outboxModel) Prune(ctx context.Context, before time.Time) (int64, error) {
+	result := m.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM outbox_consumers c WHERE c.last_seen_at >= ? AND "+
+			"NOT EXISTS (SELECT 1 FROM outbox_deliveries d WHERE d.event_id = outbox_events.id AND d.consumer = c.name))", before).
+		Delete(&entities.OutboxEvent{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
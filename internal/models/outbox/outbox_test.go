@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"task-management/internal/entities"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMock(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{
+		Logger:                 logger.Default.LogMode(logger.Silent),
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return gormDB, mock
+}
+
+func Test_outboxModel_Relay(t *testing.T) {
+	tests := []struct {
+		name       string
+		claimed    bool
+		publishErr error
+		want       int
+		wantErr    bool
+	}{
+		{name: "published and recorded", claimed: true, want: 2},
+		{name: "claimed by another relay", claimed: false},
+		{name: "publishing failed", claimed: true, publishErr: errors.New("connection refused"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB, mock := newMock(t)
+
+			mock.ExpectExec(`INSERT INTO "outbox_consumers" .* ON CONFLICT \("name"\) DO UPDATE SET "last_seen_at"=now\(\)`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			claimed := int64(0)
+			if tt.claimed {
+				claimed = 1
+			}
+			mock.ExpectExec(`UPDATE "outbox_consumers" SET "claim"=\$1,"claimed_until"=now\(\) \+ make_interval\(secs => \$2\) WHERE name = \$3 AND \(claimed_until IS NULL OR claimed_until < now\(\)\)`).
+				WithArgs(sqlmock.AnyArg(), 60.0, "webhooks").
+				WillReturnResult(sqlmock.NewResult(0, claimed))
+
+			if tt.claimed {
+				mock.ExpectQuery(`SELECT \* FROM "outbox_events" WHERE NOT EXISTS .* ORDER BY id LIMIT \$2`).
+					WithArgs("webhooks", 100).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "type"}).AddRow(1, "task.created").AddRow(2, "task.updated"))
+
+				// The batch is only recorded in a transaction after being published
+				if tt.publishErr == nil {
+					mock.ExpectBegin()
+					mock.ExpectExec(`INSERT INTO "outbox_deliveries" .* ON CONFLICT DO NOTHING`).
+						WillReturnResult(sqlmock.NewResult(0, 2))
+				}
+				mock.ExpectExec(`UPDATE "outbox_consumers" SET "claim"=\$1,"claimed_until"=\$2 WHERE name = \$3 AND claim = \$4`).
+					WithArgs(nil, nil, "webhooks", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				if tt.publishErr == nil {
+					mock.ExpectCommit()
+				}
+			}
+
+			var published []entities.OutboxEvent
+			m := &outboxModel{db: gormDB}
+			got, err := m.Relay(context.Background(), "webhooks", 100, time.Minute, func(events []entities.OutboxEvent) error {
+				published = events
+				return tt.publishErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("outboxModel.Relay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("outboxModel.Relay() = %d, want %d", got, tt.want)
+			}
+			if tt.claimed != (len(published) == 2) {
+				t.Errorf("outboxModel.Relay() published %d events", len(published))
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -16,6 +16,54 @@ type Task struct {
 	mock.Mock
 }
 
+// AddLabels provides a mock function with given fields: ctx, id, labels
+func (_m *Task) AddLabels(ctx context.Context, id uuid.UUID, labels []entities.Label) error {
+	ret := _m.Called(ctx, id, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []entities.Label) error); ok {
+		r0 = rf(ctx, id, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountByStatus provides a mock function with given fields: ctx
+func (_m *Task) CountByStatus(ctx context.Context) (map[entities.TaskStatus]int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByStatus")
+	}
+
+	var r0 map[entities.TaskStatus]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[entities.TaskStatus]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[entities.TaskStatus]int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[entities.TaskStatus]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, _a1
 func (_m *Task) Create(ctx context.Context, _a1 *entities.Task) error {
 	ret := _m.Called(ctx, _a1)
@@ -34,6 +82,24 @@ func (_m *Task) Create(ctx context.Context, _a1 *entities.Task) error {
 	return r0
 }
 
+// CreateBatch provides a mock function with given fields: ctx, tasks, batchSize
+func (_m *Task) CreateBatch(ctx context.Context, tasks []entities.Task, batchSize int) error {
+	ret := _m.Called(ctx, tasks, batchSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.Task, int) error); ok {
+		r0 = rf(ctx, tasks, batchSize)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *Task) Delete(ctx context.Context, id uuid.UUID) error {
 	ret := _m.Called(ctx, id)
@@ -52,9 +118,9 @@ func (_m *Task) Delete(ctx context.Context, id uuid.UUID) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: ctx, page, pageSize, status
-func (_m *Task) GetAll(ctx context.Context, page int, pageSize int, status *entities.TaskStatus) ([]entities.Task, error) {
-	ret := _m.Called(ctx, page, pageSize, status)
+// GetAll provides a mock function with given fields: ctx, opts
+func (_m *Task) GetAll(ctx context.Context, opts entities.TaskListOptions) ([]entities.Task, error) {
+	ret := _m.Called(ctx, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
@@ -62,19 +128,19 @@ func (_m *Task) GetAll(ctx context.Context, page int, pageSize int, status *enti
 
 	var r0 []entities.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *entities.TaskStatus) ([]entities.Task, error)); ok {
-		return rf(ctx, page, pageSize, status)
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskListOptions) ([]entities.Task, error)); ok {
+		return rf(ctx, opts)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *entities.TaskStatus) []entities.Task); ok {
-		r0 = rf(ctx, page, pageSize, status)
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskListOptions) []entities.Task); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entities.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, *entities.TaskStatus) error); ok {
-		r1 = rf(ctx, page, pageSize, status)
+	if rf, ok := ret.Get(1).(func(context.Context, entities.TaskListOptions) error); ok {
+		r1 = rf(ctx, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -112,6 +178,72 @@ func (_m *Task) GetByID(ctx context.Context, id uuid.UUID) (*entities.Task, erro
 	return r0, r1
 }
 
+// Iterate provides a mock function with given fields: ctx, opts, fn
+func (_m *Task) Iterate(ctx context.Context, opts entities.TaskListOptions, fn func(*entities.Task) error) error {
+	ret := _m.Called(ctx, opts, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Iterate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskListOptions, func(*entities.Task) error) error); ok {
+		r0 = rf(ctx, opts, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveLabels provides a mock function with given fields: ctx, id, names
+func (_m *Task) RemoveLabels(ctx context.Context, id uuid.UUID, names []string) error {
+	ret := _m.Called(ctx, id, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) error); ok {
+		r0 = rf(ctx, id, names)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, opts
+func (_m *Task) Search(ctx context.Context, opts entities.TaskSearchOptions) ([]entities.TaskSearchHit, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []entities.TaskSearchHit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskSearchOptions) ([]entities.TaskSearchHit, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskSearchOptions) []entities.TaskSearchHit); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.TaskSearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entities.TaskSearchOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, _a1
 func (_m *Task) Update(ctx context.Context, _a1 *entities.Task) error {
 	ret := _m.Called(ctx, _a1)
@@ -130,6 +262,24 @@ func (_m *Task) Update(ctx context.Context, _a1 *entities.Task) error {
 	return r0
 }
 
+// UpdateStatus provides a mock function with given fields: ctx, id, status
+func (_m *Task) UpdateStatus(ctx context.Context, id uuid.UUID, status entities.TaskStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entities.TaskStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTask creates a new instance of Task. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTask(t interface {
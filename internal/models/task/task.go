@@ -2,6 +2,9 @@ package task
 
 import (
 	"context"
+	"html"
+	"regexp"
+	"strings"
 
 	"task-management/internal/entities"
 
@@ -12,10 +15,17 @@ import (
 // Task interface defines methods for task data operations
 type Task interface {
 	Create(ctx context.Context, task *entities.Task) error
+	CreateBatch(ctx context.Context, tasks []entities.Task, batchSize int) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Task, error)
-	GetAll(ctx context.Context, page, pageSize int, status *entities.TaskStatus) ([]entities.Task, error)
+	GetAll(ctx context.Context, opts entities.TaskListOptions) ([]entities.Task, error)
+	Iterate(ctx context.Context, opts entities.TaskListOptions, fn func(task *entities.Task) error) error
+	Search(ctx context.Context, opts entities.TaskSearchOptions) ([]entities.TaskSearchHit, error)
 	Update(ctx context.Context, task *entities.Task) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status entities.TaskStatus) error
+	AddLabels(ctx context.Context, id uuid.UUID, labels []entities.Label) error
+	RemoveLabels(ctx context.Context, id uuid.UUID, names []string) error
+	CountByStatus(ctx context.Context) (map[entities.TaskStatus]int64, error)
 }
 
 type taskModel struct {
@@ -41,6 +51,21 @@ func (m *taskModel) Create(ctx context.Context, task *entities.Task) error {
 	return m.db.WithContext(ctx).Create(task).Error
 }
 
+// CreateBatch adds several tasks, inserting them batchSize at a time
+func (m *taskModel) CreateBatch(ctx context.Context, tasks []entities.Task, batchSize int) error {
+	for i := range tasks {
+		if tasks[i].ID == uuid.Nil {
+			id, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			tasks[i].ID = id
+		}
+	}
+
+	return m.db.WithContext(ctx).CreateInBatches(tasks, batchSize).Error
+}
+
 // GetByID retrieves a task by its ID
 func (m *taskModel) GetByID(ctx context.Context, id uuid.UUID) (*entities.Task, error) {
 	var task entities.Task
@@ -52,36 +77,254 @@ func (m *taskModel) GetByID(ctx context.Context, id uuid.UUID) (*entities.Task,
 	return &task, nil
 }
 
-// GetAll retrieves all tasks with pagination and optional status filtering
-func (m *taskModel) GetAll(ctx context.Context, page, pageSize int, status *entities.TaskStatus) ([]entities.Task, error) {
+// GetAll retrieves all tasks with pagination, optional status filtering and ordering
+func (m *taskModel) GetAll(ctx context.Context, opts entities.TaskListOptions) ([]entities.Task, error) {
 	var tasks []entities.Task
+	query := m.listQuery(ctx, opts)
+
+	// Apply pagination
+	if opts.Page > 0 && opts.PageSize > 0 {
+		offset := (opts.Page - 1) * opts.PageSize
+		query = query.Offset(offset).Limit(opts.PageSize)
+	}
+
+	result := query.Find(&tasks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tasks, nil
+}
+
+// Iterate streams every task matching the filters and ordering of opts to
+// fn, reading them from a database cursor instead of loading them at once.
+// Pagination is ignored. Iteration stops at the first error returned by fn.
+func (m *taskModel) Iterate(ctx context.Context, opts entities.TaskListOptions, fn func(task *entities.Task) error) error {
+	query := m.listQuery(ctx, opts).Model(&entities.Task{})
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task entities.Task
+		if err := query.ScanRows(rows, &task); err != nil {
+			return err
+		}
+		if err := fn(&task); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// listQuery applies the filters and ordering shared by listings and exports
+func (m *taskModel) listQuery(ctx context.Context, opts entities.TaskListOptions) *gorm.DB {
 	query := m.db.WithContext(ctx)
 
 	// Apply status filter if provided
-	if status != nil {
-		query = query.Where("status = ?", *status)
+	if opts.Status != nil {
+		query = query.Where("status = ?", *opts.Status)
+	}
+
+	// Apply the compiled filter expression if provided
+	if opts.Filter != nil {
+		query = query.Where(opts.Filter.Clause, opts.Filter.Args...)
+	}
+
+	// Apply ordering
+	return applySort(query, opts.Sort)
+}
+
+// applySort orders the query by the given keys, keeping due dates without
+// a value at the end and breaking ties on id so pages never overlap.
+// The id tiebreak follows the direction of the first key so single key
+// sorts can be served by the (column, id) indexes.
+func applySort(query *gorm.DB, sorts []entities.TaskSort) *gorm.DB {
+	if len(sorts) == 0 {
+		sorts = entities.DefaultTaskSort
+	}
+
+	for _, s := range sorts {
+		column, ok := entities.TaskSortColumns[s.Field]
+		if !ok {
+			continue
+		}
+
+		order := column
+		if s.Desc {
+			order += " DESC"
+		}
+		if s.Field == "due_date" {
+			order += " NULLS LAST"
+		}
+		query = query.Order(order)
+	}
+
+	if sorts[0].Desc {
+		return query.Order("id DESC")
+	}
+	return query.Order("id")
+}
+
+// searchTerm matches the words kept from a free text search query
+var searchTerm = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// ts_headline copies the text as is, so matches are delimited with private
+// use characters and turned into <mark> once the text is HTML-escaped
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+)
+
+// headlineOptions controls the fragments returned by ts_headline, the
+// whole title is kept
+const (
+	headlineOptions      = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", MaxFragments=2, MaxWords=20, MinWords=5"
+	titleHeadlineOptions = "HighlightAll=true, StartSel=" + highlightStart + ", StopSel=" + highlightStop
+)
+
+// highlightMarks turns the delimiters of ts_headline into <mark> elements
+var highlightMarks = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// Search runs a ranked full-text search over title and description. Every
+// word of the query must match, either fully or as a prefix. Highlights are
+// HTML: the text is escaped and matches are wrapped in <mark>.
+func (m *taskModel) Search(ctx context.Context, opts entities.TaskSearchOptions) ([]entities.TaskSearchHit, error) {
+	tsQuery := buildTSQuery(opts.Query)
+	if tsQuery == "" {
+		return nil, entities.ErrEmptyQuery
+	}
+
+	var hits []entities.TaskSearchHit
+	query := m.db.WithContext(ctx).
+		Table("tasks").
+		Select("tasks.*, "+
+			"ts_rank_cd(tasks.search_vector, q) AS rank, "+
+			"ts_headline('english', tasks.title, q, ?) AS title_highlight, "+
+			"ts_headline('english', tasks.description, q, ?) AS description_highlight", titleHeadlineOptions, headlineOptions).
+		Joins("CROSS JOIN to_tsquery('english', ?) AS q", tsQuery).
+		Where("tasks.search_vector @@ q").
+		// Table queries skip the soft delete scope of the model
+		Where("tasks.deleted_at IS NULL")
+
+	// Apply status filter if provided
+	if opts.Status != nil {
+		query = query.Where("tasks.status = ?", *opts.Status)
+	}
+	if opts.Filter != nil {
+		query = query.Where(opts.Filter.Clause, opts.Filter.Args...)
 	}
 
 	// Apply pagination
-	if page > 0 && pageSize > 0 {
-		offset := (page - 1) * pageSize
-		query = query.Offset(offset).Limit(pageSize)
+	if opts.Page > 0 && opts.PageSize > 0 {
+		offset := (opts.Page - 1) * opts.PageSize
+		query = query.Offset(offset).Limit(opts.PageSize)
 	}
 
-	result := query.Find(&tasks)
+	result := query.Order("rank DESC").Order("tasks.id").Scan(&hits)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return tasks, nil
+	for i := range hits {
+		hits[i].TitleHighlight = highlight(hits[i].TitleHighlight)
+		hits[i].DescriptionHighlight = highlight(hits[i].DescriptionHighlight)
+	}
+
+	return hits, nil
+}
+
+// highlight escapes a ts_headline fragment and marks its matches. Delimiters
+// typed by users only add stray <mark> elements, never markup of their own.
+func highlight(fragment string) string {
+	return highlightMarks.Replace(html.EscapeString(fragment))
+}
+
+// buildTSQuery turns free text into a to_tsquery expression. Only letters and
+// digits are kept so user input can never alter the query syntax.
+func buildTSQuery(text string) string {
+	terms := searchTerm.FindAllString(strings.ToLower(text), -1)
+	for i := range terms {
+		terms[i] += ":*"
+	}
+
+	return strings.Join(terms, " & ")
 }
 
-// Update updates an existing task
+// Update updates an existing task and replaces its labels. Nil labels are
+// left as they are, an empty slice removes them all.
 func (m *taskModel) Update(ctx context.Context, task *entities.Task) error {
-	return m.db.WithContext(ctx).Save(task).Error
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Labels").Save(task).Error; err != nil {
+			return err
+		}
+		if task.Labels == nil {
+			return nil
+		}
+		return tx.Model(task).Association("Labels").Replace(task.Labels)
+	})
 }
 
-// Delete removes a task by its ID
+// Delete marks a task deleted by its ID. Its labels are kept until the task
+// is purged.
 func (m *taskModel) Delete(ctx context.Context, id uuid.UUID) error {
 	return m.db.WithContext(ctx).Delete(&entities.Task{}, "id = ?", id).Error
 }
+
+// UpdateStatus changes the status of a task
+func (m *taskModel) UpdateStatus(ctx context.Context, id uuid.UUID, status entities.TaskStatus) error {
+	result := m.db.WithContext(ctx).Model(&entities.Task{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// AddLabels attaches labels to a task, keeping the labels it already has
+func (m *taskModel) AddLabels(ctx context.Context, id uuid.UUID, labels []entities.Label) error {
+	return m.db.WithContext(ctx).Model(&entities.Task{ID: id}).Association("Labels").Append(labels)
+}
+
+// RemoveLabels detaches the labels with the given names from a task
+func (m *taskModel) RemoveLabels(ctx context.Context, id uuid.UUID, names []string) error {
+	normalized := make([]string, len(names))
+	for i, name := range names {
+		normalized[i] = entities.NormalizeLabel(name)
+	}
+
+	return m.db.WithContext(ctx).Exec(
+		"DELETE FROM task_labels USING labels "+
+			"WHERE task_labels.label_id = labels.id AND task_labels.task_id = ? AND labels.name IN ?",
+		id, normalized,
+	).Error
+}
+
+// CountByStatus returns the number of tasks in each status, omitting
+// statuses without tasks
+func (m *taskModel) CountByStatus(ctx context.Context) (map[entities.TaskStatus]int64, error) {
+	var rows []struct {
+		Status entities.TaskStatus
+		Count  int64
+	}
+	err := m.db.WithContext(ctx).Model(&entities.Task{}).
+		Select("status, count(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[entities.TaskStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
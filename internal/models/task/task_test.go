@@ -86,11 +86,11 @@ func Test_taskModel_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.wantErr {
 				mock.ExpectBegin()
-				mock.ExpectQuery(stmt).WillReturnError(errors.New("DB Closed"))
+				mock.ExpectExec(stmt).WillReturnError(errors.New("DB Closed"))
 				mock.ExpectRollback()
 			} else {
 				mock.ExpectBegin()
-				mock.ExpectQuery(stmt).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec(stmt).WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			}
 
@@ -119,7 +119,7 @@ func Test_taskModel_GetByID(t *testing.T) {
 	}
 
 	stmt := regexp.QuoteMeta(
-		"SELECT * FROM \"tasks\" WHERE \"tasks\".\"id\" = $1 AND \"tasks\".\"deleted_at\" IS NULL ORDER BY \"tasks\".\"id\" LIMIT 1")
+		"SELECT * FROM \"tasks\" WHERE id = $1 AND \"tasks\".\"deleted_at\" IS NULL ORDER BY \"tasks\".\"id\" LIMIT $2")
 
 	type args struct {
 		ctx context.Context
@@ -176,6 +176,218 @@ func Test_taskModel_GetByID(t *testing.T) {
 	}
 }
 
+func Test_taskModel_GetAll(t *testing.T) {
+	gormDB, mock := NewMock()
+
+	defer func() {
+		db, _ := gormDB.DB()
+		db.Close()
+	}()
+
+	pendingStatus := entities.StatusPending
+
+	type args struct {
+		ctx  context.Context
+		opts entities.TaskListOptions
+	}
+
+	tests := []struct {
+		name    string
+		m       *taskModel
+		args    args
+		stmt    string
+		wantErr bool
+	}{
+		{
+			name: "Default ordering",
+			m:    &taskModel{db: gormDB},
+			args: args{
+				ctx:  context.Background(),
+				opts: entities.TaskListOptions{},
+			},
+			stmt:    `SELECT * FROM "tasks" WHERE "tasks"."deleted_at" IS NULL ORDER BY created_at DESC,id DESC`,
+			wantErr: false,
+		},
+		{
+			name: "Multi key ordering with filter and pagination",
+			m:    &taskModel{db: gormDB},
+			args: args{
+				ctx: context.Background(),
+				opts: entities.TaskListOptions{
+					Page:     2,
+					PageSize: 10,
+					Status:   &pendingStatus,
+					Sort: []entities.TaskSort{
+						{Field: "due_date", Desc: true},
+						{Field: "title"},
+					},
+				},
+			},
+			stmt:    `SELECT * FROM "tasks" WHERE status = $1 AND "tasks"."deleted_at" IS NULL ORDER BY due_date DESC NULLS LAST,title,id DESC LIMIT $2 OFFSET $3`,
+			wantErr: false,
+		},
+		{
+			name: "Failed get all",
+			m:    &taskModel{db: gormDB},
+			args: args{
+				ctx: context.Background(),
+				opts: entities.TaskListOptions{
+					Sort: []entities.TaskSort{{Field: "created_at"}},
+				},
+			},
+			stmt:    `SELECT * FROM "tasks" WHERE "tasks"."deleted_at" IS NULL ORDER BY created_at,id`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.stmt)).WillReturnError(errors.New("DB Closed"))
+			} else {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.stmt)).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}))
+			}
+
+			_, err := tt.m.GetAll(tt.args.ctx, tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("taskModel.GetAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("taskModel.GetAll() unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_taskModel_Iterate(t *testing.T) {
+	gormDB, mock := NewMock()
+
+	defer func() {
+		db, _ := gormDB.DB()
+		db.Close()
+	}()
+
+	firstID, _ := uuid.NewV4()
+	secondID, _ := uuid.NewV4()
+	pendingStatus := entities.StatusPending
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tasks" WHERE status = $1 AND "tasks"."deleted_at" IS NULL ORDER BY title,id`)).
+		WithArgs(pendingStatus).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(firstID, "A").AddRow(secondID, "B"))
+
+	m := &taskModel{db: gormDB}
+
+	var got []uuid.UUID
+	err := m.Iterate(context.Background(), entities.TaskListOptions{
+		Page:     3,
+		PageSize: 10,
+		Status:   &pendingStatus,
+		Sort:     []entities.TaskSort{{Field: "title"}},
+	}, func(task *entities.Task) error {
+		got = append(got, task.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("taskModel.Iterate() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != firstID || got[1] != secondID {
+		t.Errorf("taskModel.Iterate() = %v, want [%v %v]", got, firstID, secondID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("taskModel.Iterate() unmet expectations: %v", err)
+	}
+}
+
+func Test_taskModel_Search(t *testing.T) {
+	gormDB, mock := NewMock()
+
+	defer func() {
+		db, _ := gormDB.DB()
+		db.Close()
+	}()
+
+	taskID, _ := uuid.NewV4()
+	completedStatus := entities.StatusCompleted
+
+	type args struct {
+		ctx  context.Context
+		opts entities.TaskSearchOptions
+	}
+
+	tests := []struct {
+		name    string
+		m       *taskModel
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Successful search",
+			m:    &taskModel{db: gormDB},
+			args: args{
+				ctx:  context.Background(),
+				opts: entities.TaskSearchOptions{Query: "Login bug!", Page: 1, PageSize: 10, Status: &completedStatus},
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "Empty query",
+			m:    &taskModel{db: gormDB},
+			args: args{
+				ctx:  context.Background(),
+				opts: entities.TaskSearchOptions{Query: " ?! "},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.wantErr {
+				mock.ExpectQuery(`CROSS JOIN to_tsquery\('english', \$3\) AS q WHERE tasks.search_vector @@ q AND tasks.deleted_at IS NULL AND tasks.status = \$4 ORDER BY rank DESC,tasks.id LIMIT \$5`).
+					WithArgs(titleHeadlineOptions, headlineOptions, "login:* & bug:*", completedStatus, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "rank", "title_highlight"}).
+						AddRow(taskID, "Fix <b>login</b> bug", 0.5, "Fix <b>"+highlightStart+"login"+highlightStop+"</b> "+highlightStart+"bug"+highlightStop))
+			}
+
+			got, err := tt.m.Search(tt.args.ctx, tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("taskModel.Search() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("taskModel.Search() returned %d hits, want %d", len(got), tt.want)
+				return
+			}
+			if tt.want > 0 && (got[0].ID != taskID || got[0].Rank != 0.5 || got[0].TitleHighlight != "Fix &lt;b&gt;<mark>login</mark>&lt;/b&gt; <mark>bug</mark>") {
+				t.Errorf("taskModel.Search() = %+v", got[0])
+			}
+		})
+	}
+}
+
+func Test_buildTSQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "single word", text: "login", want: "login:*"},
+		{name: "several words", text: "Login  BUG", want: "login:* & bug:*"},
+		{name: "operators are dropped", text: "a|b & !c:*", want: "a:* & b:* & c:*"},
+		{name: "no words", text: "'&!", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildTSQuery(tt.text); got != tt.want {
+				t.Errorf("buildTSQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_taskModel_Update(t *testing.T) {
 	gormDB, mock := NewMock()
 
@@ -226,10 +438,14 @@ func Test_taskModel_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// The task and its labels are saved in one transaction
+			mock.ExpectBegin()
 			if tt.wantErr {
 				mock.ExpectExec(stmt).WillReturnError(errors.New("DB Closed"))
+				mock.ExpectRollback()
 			} else {
 				mock.ExpectExec(stmt).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			}
 
 			err := tt.m.Update(tt.args.ctx, &tt.args.task)
@@ -240,59 +456,45 @@ func Test_taskModel_Update(t *testing.T) {
 	}
 }
 
-// func Test_taskModel_Delete(t *testing.T) {
-// 	gormDB, mock := NewMock()
-
-// 	defer func() {
-// 		db, _ := gormDB.DB()
-// 		db.Close()
-// 	}()
-
-// 	taskID, _ := uuid.NewV4()
-
-// 	stmt := regexp.QuoteMeta(
-// 		"DELETE FROM \"tasks\" WHERE \"tasks\".\"id\" = $1")
-
-// 	type args struct {
-// 		ctx context.Context
-// 		id  uuid.UUID
-// 	}
-
-// 	tests := []struct {
-// 		name    string
-// 		m       *taskModel
-// 		args    args
-// 		wantErr bool
-// 	}{
-// 		{
-// 			name: "Successful delete",
-// 			m:    &taskModel{db: gormDB},
-// 			args: args{
-// 				ctx: context.Background(),
-// 				id:  taskID,
-// 			},
-// 			wantErr: false,
-// 		},
-// 		{
-// 			name: "Failed delete",
-// 			m:    &taskModel{db: gormDB},
-// 			args: args{
-// 				ctx: context.Background(),
-// 				id:  taskID,
-// 			},
-// 			wantErr: true,
-// 		},
-// 	}
-
-// 	for _, tt := range tests {
-// 		t.Run(tt.name, func(t *testing.T) {
-// 			if (err := tt.m.Delete(tt.args.ctx, tt.args.id)) != nil {
-// 				if !tt.wantErr {
-// 					t.Errorf("taskModel.Delete() error = %v, wantErr %v", err, tt.wantErr)
-// 				}
-// 			} else if tt.wantErr {
-// 				t.Errorf("taskModel.Delete() error = nil, wantErr %v", tt.wantErr)
-// 			}
-// 		})
-// 	}
-// }
+func Test_taskModel_Delete(t *testing.T) {
+	gormDB, mock := NewMock()
+
+	defer func() {
+		db, _ := gormDB.DB()
+		db.Close()
+	}()
+
+	taskID, _ := uuid.NewV4()
+
+	// Tasks are only marked deleted, purging removes them
+	stmt := regexp.QuoteMeta(`UPDATE "tasks" SET "deleted_at"=$1 WHERE id = $2 AND "tasks"."deleted_at" IS NULL`)
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "Successful delete"},
+		{name: "Failed delete", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.wantErr {
+				mock.ExpectExec(stmt).WithArgs(sqlmock.AnyArg(), taskID).WillReturnError(errors.New("DB Closed"))
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(stmt).WithArgs(sqlmock.AnyArg(), taskID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			m := &taskModel{db: gormDB}
+			if err := m.Delete(context.Background(), taskID); (err != nil) != tt.wantErr {
+				t.Errorf("taskModel.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("taskModel.Delete() unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package view
+
+import (
+	"context"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// View interface defines methods for saved view data operations
+type View interface {
+	Create(ctx context.Context, view *entities.View) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.View, error)
+	GetVisible(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID) ([]entities.View, error)
+	Update(ctx context.Context, view *entities.View) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type viewModel struct {
+	db *gorm.DB
+}
+
+// New creates a new instance of View
+func New(db *gorm.DB) View {
+	return &viewModel{db: db}
+}
+
+// Create adds a new view to the database
+func (m *viewModel) Create(ctx context.Context, view *entities.View) error {
+	// Generate a new UUID if not provided
+	if view.ID == uuid.Nil {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		view.ID = id
+	}
+
+	return m.db.WithContext(ctx).Create(view).Error
+}
+
+// GetByID retrieves a view by its ID
+func (m *viewModel) GetByID(ctx context.Context, id uuid.UUID) (*entities.View, error) {
+	var view entities.View
+	result := m.db.WithContext(ctx).First(&view, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &view, nil
+}
+
+// GetVisible retrieves the views owned by the user along with the views
+// shared with the given project
+func (m *viewModel) GetVisible(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID) ([]entities.View, error) {
+	var views []entities.View
+	query := m.db.WithContext(ctx).Where("user_id = ?", userID)
+
+	if projectID != nil {
+		query = query.Or("shared AND project_id = ?", *projectID)
+	}
+
+	result := query.Order("name").Order("id").Find(&views)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return views, nil
+}
+
+// Update updates an existing view
+func (m *viewModel) Update(ctx context.Context, view *entities.View) error {
+	return m.db.WithContext(ctx).Save(view).Error
+}
+
+// Delete removes a view by its ID
+func (m *viewModel) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.db.WithContext(ctx).Delete(&entities.View{}, "id = ?", id).Error
+}
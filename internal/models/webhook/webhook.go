@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// deliveryBatchSize is the number of deliveries inserted per statement
+const deliveryBatchSize = 500
+
+// Webhook interface defines methods for webhook subscription and delivery
+// data operations
+type Webhook interface {
+	Create(ctx context.Context, subscription *entities.WebhookSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+	GetByUser(ctx context.Context, userID uuid.UUID) ([]entities.WebhookSubscription, error)
+	GetActive(ctx context.Context) ([]entities.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entities.WebhookSubscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CreateDeliveries(ctx context.Context, deliveries []entities.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, page, pageSize int) ([]entities.WebhookDelivery, error)
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]entities.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+}
+
+type webhookModel struct {
+	db *gorm.DB
+}
+
+// New creates a new instance of Webhook
+func New(db *gorm.DB) Webhook {
+	return &webhookModel{db: db}
+}
+
+// Create adds a new subscription to the database
+func (m *webhookModel) Create(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	// Generate a new UUID if not provided
+	if subscription.ID == uuid.Nil {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		subscription.ID = id
+	}
+
+	return m.db.WithContext(ctx).Create(subscription).Error
+}
+
+// GetByID retrieves a subscription by its ID
+func (m *webhookModel) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	var subscription entities.WebhookSubscription
+	result := m.db.WithContext(ctx).First(&subscription, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &subscription, nil
+}
+
+// GetByUser retrieves the subscriptions of a user
+func (m *webhookModel) GetByUser(ctx context.Context, userID uuid.UUID) ([]entities.WebhookSubscription, error) {
+	var subscriptions []entities.WebhookSubscription
+	result := m.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Order("id").Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscriptions, nil
+}
+
+// GetActive retrieves every active subscription
+func (m *webhookModel) GetActive(ctx context.Context) ([]entities.WebhookSubscription, error) {
+	var subscriptions []entities.WebhookSubscription
+	result := m.db.WithContext(ctx).Where("active").Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscriptions, nil
+}
+
+// Update updates an existing subscription
+func (m *webhookModel) Update(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	return m.db.WithContext(ctx).Save(subscription).Error
+}
+
+// Delete removes a subscription by its ID. Its deliveries are removed by
+// the database along with it.
+func (m *webhookModel) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.db.WithContext(ctx).Delete(&entities.WebhookSubscription{}, "id = ?", id).Error
+}
+
+// CreateDeliveries queues deliveries, inserting them in batches. Deliveries
+// already queued for the same event and subscription are left as they are.
+func (m *webhookModel) CreateDeliveries(ctx context.Context, deliveries []entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	for i := range deliveries {
+		if deliveries[i].ID == uuid.Nil {
+			id, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			deliveries[i].ID = id
+		}
+	}
+
+	return m.db.WithContext(ctx).
+		Omit("Subscription").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(deliveries, deliveryBatchSize).Error
+}
+
+// GetDeliveries retrieves the deliveries of a subscription, newest first
+func (m *webhookModel) GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, page, pageSize int) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+	query := m.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+
+	// Apply pagination
+	if page > 0 && pageSize > 0 {
+		offset := (page - 1) * pageSize
+		query = query.Offset(offset).Limit(pageSize)
+	}
+
+	result := query.Order("created_at DESC").Order("id DESC").Find(&deliveries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDue picks up to limit pending deliveries whose next attempt is due,
+// along with their subscription. Claimed deliveries are pushed back by lease
+// so other workers skip them while they are being sent; a worker that dies
+// mid-delivery has them retried once the lease runs out.
+func (m *webhookModel) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Model(&entities.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entities.DeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		err = tx.Model(&entities.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Preload("Subscription").Where("id IN ?", ids).Order("next_attempt_at").Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt
+func (m *webhookModel) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	return m.db.WithContext(ctx).Omit("Subscription").Save(delivery).Error
+}
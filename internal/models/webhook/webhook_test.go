@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"task-management/internal/entities"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofrs/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func Test_webhookModel_Create(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, active := range []bool{true, false} {
+		subscription := entities.WebhookSubscription{
+			UserID: uuid.Must(uuid.NewV4()),
+			URL:    "https://example.com/hooks",
+			Secret: "secret",
+			Active: active,
+		}
+
+		// The flag is always written, a tag default would replace false
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "webhook_subscriptions" .*"active"`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), subscription.UserID, nil, subscription.URL, subscription.Secret, "", active).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := New(db).Create(context.Background(), &subscription); err != nil {
+			t.Fatalf("Create() with active %v error = %v", active, err)
+		}
+		if subscription.ID == uuid.Nil || subscription.Active != active {
+			t.Errorf("Create() = %+v, want an ID and active %v", subscription, active)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,61 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"task-management/internal/entities"
+)
+
+// HTTPPublisher posts each batch of events as a JSON array to a URL
+type HTTPPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPublisher creates a publisher posting to url
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Name implements Publisher
+func (p *HTTPPublisher) Name() string {
+	return "http"
+}
+
+// Publish implements Publisher. Any answer outside 2xx fails the batch.
+func (p *HTTPPublisher) Publish(ctx context.Context, events []entities.OutboxEvent) error {
+	payloads := make([]json.RawMessage, len(events))
+	for i, event := range events {
+		payloads[i] = json.RawMessage(event.Payload)
+	}
+
+	body, err := json.Marshal(payloads)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s answered %s", p.url, resp.Status)
+	}
+
+	return nil
+}
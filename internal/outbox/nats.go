@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"task-management/internal/entities"
+)
+
+// natsTimeout bounds connecting to the server and publishing a batch
+const natsTimeout = 10 * time.Second
+
+// NATSPublisher publishes events to a NATS compatible server using the core
+// text protocol, one message per event on "<subject>.<event type>", e.g.
+// tasks.task.created. Each batch is confirmed with a PING/PONG round trip so
+// the server has processed every message before the batch is recorded.
+// TLS connections are not supported.
+type NATSPublisher struct {
+	addr    string
+	user    string
+	pass    string
+	subject string
+}
+
+// NewNATSPublisher creates a publisher for a nats://[user:pass@]host:port URL
+func NewNATSPublisher(rawURL, subject string) (*NATSPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" || u.Host == "" {
+		return nil, fmt.Errorf("invalid NATS URL %q, expected nats://host:port", rawURL)
+	}
+
+	p := &NATSPublisher{addr: u.Host, subject: subject}
+	if u.Port() == "" {
+		p.addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if u.User != nil {
+		p.user = u.User.Username()
+		p.pass, _ = u.User.Password()
+	}
+
+	return p, nil
+}
+
+// Name implements Publisher
+func (p *NATSPublisher) Name() string {
+	return "nats"
+}
+
+// Publish implements Publisher
+func (p *NATSPublisher) Publish(ctx context.Context, events []entities.OutboxEvent) error {
+	dialer := net.Dialer{Timeout: natsTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(natsTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	// The server greets with INFO before accepting anything
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("unexpected NATS greeting %q", strings.TrimSpace(line))
+	}
+
+	connect, err := json.Marshal(map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "task-management-outbox",
+		"lang":     "go",
+		"user":     p.user,
+		"pass":     p.pass,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "CONNECT %s\r\n", connect)
+
+	for _, event := range events {
+		fmt.Fprintf(w, "PUB %s.%s %d\r\n%s\r\n", p.subject, event.Type, len(event.Payload), event.Payload)
+	}
+	w.WriteString("PING\r\n")
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			w.WriteString("PONG\r\n")
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
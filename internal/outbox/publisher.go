@@ -0,0 +1,38 @@
+// Package outbox publishes the task events saved in the outbox table.
+package outbox
+
+import (
+	"context"
+	"log/slog"
+
+	"task-management/internal/entities"
+)
+
+// Publisher delivers outbox events to one consumer. Name identifies the
+// consumer in the outbox deliveries and must not change between releases,
+// otherwise every retained event is published again.
+//
+// Publish must deliver the whole batch or return an error, in which case the
+// batch is retried later. Since a relay can stop between publishing and
+// recording a batch, consumers may see an event twice and should ignore
+// event IDs they already handled.
+type Publisher interface {
+	Name() string
+	Publish(ctx context.Context, events []entities.OutboxEvent) error
+}
+
+// LogPublisher writes events to the standard logger
+type LogPublisher struct{}
+
+// Name implements Publisher
+func (LogPublisher) Name() string {
+	return "log"
+}
+
+// Publish implements Publisher
+func (LogPublisher) Publish(ctx context.Context, events []entities.OutboxEvent) error {
+	for _, event := range events {
+		slog.InfoContext(ctx, "outbox event", "id", event.ID, "type", event.Type, "task_id", event.TaskID, "payload", event.Payload)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"task-management/internal/entities"
+	outboxModel "task-management/internal/models/outbox"
+)
+
+const (
+	pollInterval = time.Second
+	batchSize    = 100
+
+	// claimLease keeps other relays away from a consumer while a batch is
+	// published to it. It must outlast the publishers' timeouts.
+	claimLease = time.Minute
+
+	// Retention is how long published events are kept. It bounds the
+	// outbox table and how far back a new consumer starts.
+	Retention = 7 * 24 * time.Hour
+
+	pruneInterval = time.Hour
+)
+
+// Relay publishes outbox events to every publisher. Publishers progress
+// independently, a failing one does not hold the others back.
+type Relay struct {
+	model      outboxModel.Outbox
+	publishers []Publisher
+}
+
+// NewRelay creates a relay for the given publishers
+func NewRelay(model outboxModel.Outbox, publishers ...Publisher) *Relay {
+	return &Relay{model: model, publishers: publishers}
+}
+
+// Run relays events until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		// A full batch means more events are waiting, relay them right away
+		for {
+			n, err := r.RunOnce(ctx)
+			if err != nil || n < batchSize {
+				break
+			}
+		}
+
+		if time.Since(lastPrune) > pruneInterval {
+			lastPrune = time.Now()
+			if _, err := r.model.Prune(ctx, lastPrune.Add(-Retention)); err != nil {
+				slog.ErrorContext(ctx, "outbox relay: prune failed", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce publishes one batch to every publisher and returns the largest
+// number of events published to a single one. Failures are logged and
+// retried on the next run.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	most := 0
+	var lastErr error
+
+	for _, p := range r.publishers {
+		n, err := r.model.Relay(ctx, p.Name(), batchSize, claimLease, func(events []entities.OutboxEvent) error {
+			return p.Publish(ctx, events)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "outbox relay: publishing failed", "publisher", p.Name(), "error", err)
+			lastErr = err
+			continue
+		}
+		if n > most {
+			most = n
+		}
+	}
+
+	return most, lastErr
+}
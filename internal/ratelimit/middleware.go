@@ -0,0 +1,215 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"task-management/internal/auth"
+	"task-management/internal/config"
+	"task-management/internal/logging"
+	"task-management/internal/web/httpx"
+)
+
+// exemptRoutes are polled by the infrastructure and never limited
+var exemptRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/status":  true,
+	"/metrics": true,
+}
+
+// quota is a config.Quota ready to be enforced. A zero rate leaves callers
+// unlimited.
+type quota struct {
+	limit    Limit
+	requests int
+	window   int
+}
+
+func newQuota(q config.Quota) quota {
+	if q.Requests == 0 || q.Per <= 0 {
+		return quota{}
+	}
+	burst := q.Burst
+	if burst == 0 {
+		burst = q.Requests
+	}
+	return quota{
+		limit:    Limit{Rate: float64(q.Requests) / q.Per.Seconds(), Burst: burst},
+		requests: q.Requests,
+		window:   int(math.Ceil(q.Per.Seconds())),
+	}
+}
+
+// policy is the RateLimit-Policy header value of the quota
+func (q quota) policy() string {
+	return fmt.Sprintf("%d;w=%d;burst=%d", q.requests, q.window, q.limit.Burst)
+}
+
+type rule struct {
+	name    string
+	methods map[string]bool
+	routes  map[string]bool
+	ip      quota
+	user    quota
+}
+
+// matches reports whether the rule applies to a request for route
+func (r rule) matches(method, route string) bool {
+	return (len(r.methods) == 0 || r.methods[method]) && (len(r.routes) == 0 || r.routes[route])
+}
+
+// Limiter enforces the configured quotas on the requests matched by the
+// router
+type Limiter struct {
+	store             Store
+	rules             []rule
+	trustForwardedFor bool
+	trustUserID       bool
+}
+
+// New creates a limiter enforcing the rules of cfg with the buckets of
+// store
+func New(cfg config.RateLimit, store Store) *Limiter {
+	l := &Limiter{store: store, trustForwardedFor: cfg.TrustForwardedFor, trustUserID: cfg.TrustUserID}
+	for _, r := range cfg.Rules {
+		compiled := rule{
+			name:    r.Name,
+			methods: make(map[string]bool),
+			routes:  make(map[string]bool),
+			ip:      newQuota(r.IP),
+			user:    newQuota(r.User),
+		}
+		for _, method := range r.Methods {
+			compiled.methods[strings.ToUpper(method)] = true
+		}
+		for _, route := range r.Routes {
+			compiled.routes[route] = true
+		}
+		l.rules = append(l.rules, compiled)
+	}
+	return l
+}
+
+// Middleware takes a token from the caller's buckets of the first rule
+// matching the request and answers 429 when one is empty. Every request
+// draws from its IP's bucket. When the gateway is trusted to authenticate
+// X-User-ID, identified callers also draw from a bucket of their own, so
+// rotating the header does not get around the IP's quota. Responses carry
+// the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers of the bucket closest to running out, plus
+// Retry-After when refused. Requests go through when the store fails.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := httpx.Route(r)
+		if exemptRoutes[route] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var matched *rule
+		for i := range l.rules {
+			if l.rules[i].matches(r.Method, route) {
+				matched = &l.rules[i]
+				break
+			}
+		}
+		if matched == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		draws := []draw{{quota: matched.ip, key: matched.name + ":ip:" + l.clientIP(r)}}
+		if caller, ok := auth.FromContext(r.Context()); ok && l.trustUserID {
+			draws = append(draws, draw{quota: matched.user, key: matched.name + ":user:" + caller.UserID.String()})
+		}
+
+		// reported is the bucket whose state the response headers carry
+		var reported *draw
+		for i := range draws {
+			b := &draws[i]
+			if b.quota.limit.Rate == 0 {
+				continue
+			}
+
+			var err error
+			b.result, err = l.store.Take(r.Context(), b.key, b.quota.limit)
+			if err != nil {
+				logging.FromContext(r.Context()).Warn("rate limit store failed, letting the request through", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if reported == nil || !b.result.Allowed || b.result.Tokens < reported.result.Tokens {
+				reported = b
+			}
+			if !b.result.Allowed {
+				break
+			}
+		}
+		if reported == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		q, result := reported.quota, reported.result
+		header := w.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(q.limit.Burst))
+		header.Set("RateLimit-Remaining", strconv.Itoa(int(math.Max(0, math.Floor(result.Tokens)))))
+		header.Set("RateLimit-Reset", strconv.Itoa(secondsUntil(float64(q.limit.Burst)-result.Tokens, q.limit.Rate)))
+		header.Set("RateLimit-Policy", q.policy())
+
+		if !result.Allowed {
+			header.Set("Retry-After", strconv.Itoa(secondsUntil(1-result.Tokens, q.limit.Rate)))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// draw is a bucket a request takes a token from, along with the outcome
+type draw struct {
+	quota  quota
+	key    string
+	result Result
+}
+
+// secondsUntil returns the whole seconds needed to refill tokens at rate
+func secondsUntil(tokens, rate float64) int {
+	if tokens <= 0 {
+		return 0
+	}
+	return int(math.Ceil(tokens / rate))
+}
+
+// clientIP returns the address the request comes from. IPv6 clients are
+// grouped by /64, the block usually handed to a single subscriber.
+func (l *Limiter) clientIP(r *http.Request) string {
+	addr := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	if l.trustForwardedFor {
+		// The proxy appends the address it received the request from, the
+		// entries before it are whatever the client sent
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			entries := strings.Split(forwarded[len(forwarded)-1], ",")
+			if last := strings.TrimSpace(entries[len(entries)-1]); last != "" {
+				addr = last
+			}
+		}
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return addr
+	}
+	if ip.To4() == nil {
+		return ip.Mask(net.CIDRMask(64, 128)).String() + "/64"
+	}
+	return ip.String()
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"task-management/internal/logging"
+
+	"gorm.io/gorm"
+)
+
+// takeQuery refills and takes from a bucket in a single statement, so
+// concurrent requests of every instance are serialized by the row lock
+const takeQuery = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, rate, burst, allowed, updated_at)
+VALUES (@key, @burst - 1, @rate, @burst, true, now())
+ON CONFLICT (key) DO UPDATE SET
+	tokens = LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate)
+		- CASE WHEN LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate) >= 1 THEN 1 ELSE 0 END,
+	allowed = LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate) >= 1,
+	rate = @rate,
+	burst = @burst,
+	updated_at = now()
+RETURNING tokens, allowed`
+
+// pruneQuery drops the buckets refilled to the brim
+const pruneQuery = `DELETE FROM rate_limit_buckets WHERE tokens + EXTRACT(EPOCH FROM now() - updated_at) * rate >= burst`
+
+// PostgresStore keeps the buckets in the rate_limit_buckets table, shared
+// by every instance
+type PostgresStore struct {
+	db        *gorm.DB
+	lastPrune atomic.Int64
+}
+
+// NewPostgresStore creates a store over db
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	s := &PostgresStore{db: db}
+	s.lastPrune.Store(time.Now().UnixNano())
+	return s
+}
+
+// Take implements Store. A new bucket starts full, so the first request of
+// a client creates it with one token taken.
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.maybePrune(ctx)
+
+	var row struct {
+		Tokens  float64
+		Allowed bool
+	}
+	err := s.db.WithContext(ctx).Raw(takeQuery, map[string]interface{}{
+		"key":   key,
+		"rate":  limit.Rate,
+		"burst": limit.Burst,
+	}).Scan(&row).Error
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Allowed: row.Allowed, Tokens: row.Tokens}, nil
+}
+
+// maybePrune drops the full buckets when the last prune of this instance is
+// older than sweepInterval
+func (s *PostgresStore) maybePrune(ctx context.Context) {
+	last := s.lastPrune.Load()
+	now := time.Now().UnixNano()
+	if time.Duration(now-last) < sweepInterval || !s.lastPrune.CompareAndSwap(last, now) {
+		return
+	}
+
+	if err := s.db.WithContext(ctx).Exec(pruneQuery).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to prune rate limit buckets", "error", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Package ratelimit throttles API clients with token buckets. Each bucket
+// holds up to Burst tokens and is refilled at a steady rate; a request takes
+// a token and is refused with 429 when the bucket is empty. Buckets live in
+// the memory of the instance or, shared by every instance, in Postgres.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit is a bucket refilled with Rate tokens per second up to Burst
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result is the state of a bucket once a token was asked for
+type Result struct {
+	Allowed bool
+	// Tokens is what is left in the bucket
+	Tokens float64
+}
+
+// Store keeps the buckets by key
+type Store interface {
+	// Take refills the bucket of key for the time elapsed since it was last
+	// used, then takes a token from it if there is one
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// sweepInterval is how often the buckets refilled to the brim are dropped
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	limit   Limit
+	updated time.Time
+}
+
+// full reports whether the bucket is refilled by now, and so no different
+// from a new one
+func (b *bucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.updated).Seconds()*b.limit.Rate >= float64(b.limit.Burst)
+}
+
+// MemoryStore keeps the buckets in memory. Every instance then enforces the
+// limits on its own.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Take implements Store
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.lastSweep = now
+		for k, b := range s.buckets {
+			if b.full(now) {
+				delete(s.buckets, k)
+			}
+		}
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return Result{Tokens: b.tokens}, nil
+	}
+	b.tokens--
+	return Result{Allowed: true, Tokens: b.tokens}, nil
+}
@@ -0,0 +1,270 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"task-management/internal/auth"
+	"task-management/internal/config"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	limit := Limit{Rate: 1, Burst: 2}
+
+	take := func() Result {
+		t.Helper()
+		result, err := s.Take(context.Background(), "client", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	if r := take(); !r.Allowed || r.Tokens != 1 {
+		t.Errorf("first take = %+v, want allowed with 1 token left", r)
+	}
+	if r := take(); !r.Allowed || r.Tokens != 0 {
+		t.Errorf("second take = %+v, want allowed with 0 tokens left", r)
+	}
+	if r := take(); r.Allowed {
+		t.Errorf("take from an empty bucket = %+v, want refused", r)
+	}
+
+	now = now.Add(1500 * time.Millisecond)
+	if r := take(); !r.Allowed || r.Tokens != 0.5 {
+		t.Errorf("take after 1.5s = %+v, want allowed with 0.5 tokens left", r)
+	}
+
+	// A bucket left alone refills up to its burst and is then swept
+	now = now.Add(time.Hour)
+	if r := take(); !r.Allowed || r.Tokens != 1 {
+		t.Errorf("take after an hour = %+v, want a full bucket", r)
+	}
+	now = now.Add(2 * sweepInterval)
+	s.Take(context.Background(), "other", limit)
+	if _, ok := s.buckets["client"]; ok {
+		t.Error("full bucket not swept")
+	}
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	cfg := config.RateLimit{
+		Rules: []config.RateLimitRule{
+			{
+				Name:    "create",
+				Methods: []string{"post"},
+				Routes:  []string{"/api/tasks"},
+				IP:      config.Quota{Requests: 2, Per: time.Minute},
+				User:    config.Quota{Requests: 3, Per: time.Minute},
+			},
+			{
+				Name: "default",
+				IP:   config.Quota{Requests: 100, Per: time.Minute, Burst: 10},
+			},
+		},
+	}
+	serve := newTestServe(New(cfg, NewMemoryStore()))
+
+	w := serve("POST", "/api/tasks", "203.0.113.7", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("first POST = %d, want 200", w.Code)
+	}
+	for header, want := range map[string]string{
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "1",
+		"RateLimit-Reset":     "30",
+		"RateLimit-Policy":    "2;w=60;burst=2",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	serve("POST", "/api/tasks", "203.0.113.7", "")
+	w = serve("POST", "/api/tasks", "203.0.113.7", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("third POST = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want 30", got)
+	}
+
+	// Other rules, other IPs and probes have buckets of their own
+	if w := serve("GET", "/api/tasks", "203.0.113.7", ""); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "10" {
+		t.Errorf("GET = %d with limit %q, want 200 under the default rule", w.Code, w.Header().Get("RateLimit-Limit"))
+	}
+	if w := serve("POST", "/api/tasks", "203.0.113.8", ""); w.Code != http.StatusOK {
+		t.Errorf("POST from another IP = %d, want 200", w.Code)
+	}
+	for i := 0; i < 20; i++ {
+		if w := serve("GET", "/healthz", "203.0.113.7", ""); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("probe %d = %d, want it exempt", i, w.Code)
+		}
+	}
+}
+
+func TestLimiter_Middleware_userID(t *testing.T) {
+	rules := []config.RateLimitRule{{
+		Name: "default",
+		IP:   config.Quota{Requests: 3, Per: time.Minute},
+		User: config.Quota{Requests: 1, Per: time.Minute},
+	}}
+
+	t.Run("untrusted header", func(t *testing.T) {
+		serve := newTestServe(New(config.RateLimit{Rules: rules}, NewMemoryStore()))
+
+		// A new X-User-ID on every request still draws from the IP's bucket
+		for i := 0; i < 3; i++ {
+			if w := serve("GET", "/api/tasks", "203.0.113.7", uuid.Must(uuid.NewV4()).String()); w.Code != http.StatusOK {
+				t.Fatalf("request %d = %d, want 200", i, w.Code)
+			}
+		}
+		if w := serve("GET", "/api/tasks", "203.0.113.7", uuid.Must(uuid.NewV4()).String()); w.Code != http.StatusTooManyRequests {
+			t.Errorf("request with a rotated X-User-ID = %d, want 429", w.Code)
+		}
+	})
+
+	t.Run("trusted header", func(t *testing.T) {
+		serve := newTestServe(New(config.RateLimit{Rules: rules, TrustUserID: true}, NewMemoryStore()))
+
+		user := uuid.Must(uuid.NewV4()).String()
+		w := serve("GET", "/api/tasks", "203.0.113.7", user)
+		if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "1" || w.Header().Get("RateLimit-Remaining") != "0" {
+			t.Fatalf("first request = %d with limit %q, want 200 reporting the emptier user bucket", w.Code, w.Header().Get("RateLimit-Limit"))
+		}
+		if w := serve("GET", "/api/tasks", "203.0.113.8", user); w.Code != http.StatusTooManyRequests {
+			t.Errorf("second request of the user = %d, want 429 from the user bucket", w.Code)
+		}
+
+		// The IP's bucket still applies to rotated users
+		for i := 0; i < 2; i++ {
+			if w := serve("GET", "/api/tasks", "203.0.113.7", uuid.Must(uuid.NewV4()).String()); w.Code != http.StatusOK {
+				t.Fatalf("request %d of another user = %d, want 200", i, w.Code)
+			}
+		}
+		if w := serve("GET", "/api/tasks", "203.0.113.7", uuid.Must(uuid.NewV4()).String()); w.Code != http.StatusTooManyRequests {
+			t.Errorf("request with a rotated X-User-ID = %d, want 429 from the IP bucket", w.Code)
+		}
+	})
+}
+
+// newTestServe routes requests through limiter, identifying callers by
+// X-User-ID like the REST router does, and returns a function serving a
+// request from ip as userID, anonymous when empty
+func newTestServe(limiter *Limiter) func(method, path, ip, userID string) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userID, err := uuid.FromString(r.Header.Get("X-User-ID")); err == nil {
+				r = r.WithContext(auth.WithCaller(r.Context(), auth.Caller{UserID: userID}))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, limiter.Middleware)
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	router.HandleFunc("/api/tasks", ok).Methods("GET", "POST")
+	router.HandleFunc("/healthz", ok)
+
+	return func(method, path, ip, userID string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		r.RemoteAddr = ip + ":5123"
+		if userID != "" {
+			r.Header.Set("X-User-ID", userID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func TestLimiter_Middleware_storeFailure(t *testing.T) {
+	limiter := New(config.RateLimit{
+		Rules: []config.RateLimitRule{{Name: "default", IP: config.Quota{Requests: 1, Per: time.Minute}}},
+	}, failingStore{})
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/tasks", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("request with a failing store = %d, want it let through", w.Code)
+	}
+}
+
+func TestLimiter_clientIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		trust     bool
+		remote    string
+		forwarded []string
+		want      string
+	}{
+		{"remote address", false, "198.51.100.1:4000", nil, "198.51.100.1"},
+		{"untrusted header", false, "198.51.100.1:4000", []string{"203.0.113.9"}, "198.51.100.1"},
+		{"last forwarded entry", true, "10.0.0.2:4000", []string{"1.2.3.4, 203.0.113.9"}, "203.0.113.9"},
+		{"last forwarded header", true, "10.0.0.2:4000", []string{"1.2.3.4", "203.0.113.9"}, "203.0.113.9"},
+		{"IPv6 block", false, "[2001:db8:1:2:3:4:5:6]:4000", nil, "2001:db8:1:2::/64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remote
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			l := &Limiter{trustForwardedFor: tt.trust}
+			if got := l.clientIP(r); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgresStore_Take(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`INSERT INTO rate_limit_buckets .* ON CONFLICT \(key\) DO UPDATE .* RETURNING tokens, allowed`).
+		WithArgs("default:ip:203.0.113.7", 5, 0.5, 5, 5, 0.5, 5, 0.5, 5, 0.5, 0.5, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"tokens", "allowed"}).AddRow(0.25, false))
+
+	s := NewPostgresStore(db)
+	result, err := s.Take(context.Background(), "default:ip:203.0.113.7", Limit{Rate: 0.5, Burst: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Allowed || result.Tokens != 0.25 {
+		t.Errorf("Take() = %+v, want refused with 0.25 tokens", result)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
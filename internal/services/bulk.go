@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"task-management/internal/entities"
+	"task-management/internal/models"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// BulkTasks applies a batch of task operations. Atomic batches run in a
+// single transaction: the first failing item rolls back the items before it
+// and the items after it are skipped. Otherwise each item is applied in its
+// own transaction and failures do not affect the other items.
+func (s *service) BulkTasks(ctx context.Context, req *entities.BulkRequest) (*entities.BulkResponse, error) {
+	response := &entities.BulkResponse{
+		Atomic:  req.IsAtomic(),
+		Results: make([]entities.BulkItemResult, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		response.Results[i] = entities.BulkItemResult{Index: i, Op: item.Op, ID: item.ID}
+	}
+
+	if !req.IsAtomic() {
+		for i, item := range req.Items {
+			var id *uuid.UUID
+			err := s.model.Transaction(ctx, func(tx *models.Model) error {
+				var events []entities.TaskEvent
+				var err error
+				id, events, err = applyBulkItem(ctx, *tx, item)
+				if err != nil {
+					return err
+				}
+				return emit(ctx, *tx, events...)
+			})
+			response.Record(i, id, err)
+		}
+		return response, nil
+	}
+
+	failed := -1
+	err := s.model.Transaction(ctx, func(tx *models.Model) error {
+		var events []entities.TaskEvent
+		for i, item := range req.Items {
+			id, itemEvents, err := applyBulkItem(ctx, *tx, item)
+			if err != nil {
+				failed = i
+				return err
+			}
+			response.Results[i].ID = id
+			events = append(events, itemEvents...)
+		}
+		return emit(ctx, *tx, events...)
+	})
+
+	if err != nil && failed < 0 {
+		// The items went through but the commit itself failed
+		return nil, err
+	}
+
+	for i := range response.Results {
+		switch {
+		case failed < 0:
+			response.Record(i, response.Results[i].ID, nil)
+		case i < failed:
+			response.Results[i].Status = entities.BulkRolledBack
+		case i == failed:
+			response.Record(i, response.Results[i].ID, err)
+		default:
+			response.Results[i].Status = entities.BulkSkipped
+		}
+	}
+
+	return response, nil
+}
+
+// applyBulkItem runs a single bulk operation and returns the id of the task
+// it touched along with the events describing the change
+func applyBulkItem(ctx context.Context, m models.Model, item entities.BulkItem) (*uuid.UUID, []entities.TaskEvent, error) {
+	if item.Op == entities.BulkCreate {
+		if item.Task == nil {
+			return nil, nil, fmt.Errorf("%w: create needs a task", entities.ErrInvalidBulk)
+		}
+		if err := authorizeProject(ctx, item.Task.ProjectID); err != nil {
+			return nil, nil, err
+		}
+
+		task := &entities.Task{
+			Title:       item.Task.Title,
+			Description: item.Task.Description,
+			Status:      item.Task.Status,
+			DueDate:     item.Task.DueDate,
+			ProjectID:   item.Task.ProjectID,
+		}
+		if len(item.Task.Labels) > 0 {
+			labels, err := m.Label.FindOrCreate(ctx, item.Task.Labels)
+			if err != nil {
+				return nil, nil, err
+			}
+			task.Labels = labels
+		}
+
+		if err := m.Task.Create(ctx, task); err != nil {
+			return nil, nil, err
+		}
+		return &task.ID, []entities.TaskEvent{newTaskEvent(entities.EventTaskCreated, task, nil)}, nil
+	}
+
+	if item.ID == nil {
+		return nil, nil, fmt.Errorf("%w: %s needs an id", entities.ErrInvalidBulk, item.Op)
+	}
+	id := *item.ID
+
+	task, err := m.Task.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+	if err := authorizeProject(ctx, task.ProjectID); err != nil {
+		return nil, nil, err
+	}
+
+	switch item.Op {
+	case entities.BulkSetStatus:
+		if !item.Status.Valid() {
+			return nil, nil, fmt.Errorf("%w: unknown status %q", entities.ErrInvalidBulk, item.Status)
+		}
+		if err := m.Task.UpdateStatus(ctx, id, item.Status); err != nil {
+			return nil, nil, err
+		}
+
+		previous := task.Status
+		task.Status = item.Status
+		events := []entities.TaskEvent{newTaskEvent(entities.EventTaskUpdated, task, nil)}
+		if previous != item.Status {
+			events = append(events, newTaskEvent(entities.EventTaskStatusChanged, task, &previous))
+		}
+		return item.ID, events, nil
+
+	case entities.BulkDelete:
+		if err := m.Task.Delete(ctx, id); err != nil {
+			return nil, nil, err
+		}
+		return item.ID, []entities.TaskEvent{newTaskEvent(entities.EventTaskDeleted, task, nil)}, nil
+
+	case entities.BulkAddLabels, entities.BulkRemoveLabels:
+		if len(item.Labels) == 0 {
+			return nil, nil, fmt.Errorf("%w: %s needs labels", entities.ErrInvalidBulk, item.Op)
+		}
+
+		if item.Op == entities.BulkRemoveLabels {
+			err = m.Task.RemoveLabels(ctx, id, item.Labels)
+		} else {
+			var labels []entities.Label
+			labels, err = m.Label.FindOrCreate(ctx, item.Labels)
+			if err == nil {
+				err = m.Task.AddLabels(ctx, id, labels)
+			}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return item.ID, []entities.TaskEvent{newTaskEvent(entities.EventTaskUpdated, task, nil)}, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: unknown operation %q", entities.ErrInvalidBulk, item.Op)
+}
+
+// notFound translates a missing record into ErrTaskNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.ErrTaskNotFound
+	}
+	return err
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"task-management/internal/entities"
+	"task-management/internal/models"
+	labelMock "task-management/internal/models/label/mocks"
+	outboxMock "task-management/internal/models/outbox/mocks"
+	taskMock "task-management/internal/models/task/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_service_BulkTasks(t *testing.T) {
+	existingID, _ := uuid.NewV4()
+	missingID, _ := uuid.NewV4()
+	backend := entities.Label{Name: "backend"}
+
+	taskModel := taskMock.Task{}
+	taskModel.On("Create", mock.Anything, mock.Anything).Return(nil)
+	taskModel.On("UpdateStatus", mock.Anything, existingID, entities.StatusCompleted).Return(nil)
+	taskModel.On("GetByID", mock.Anything, existingID).Return(&entities.Task{ID: existingID}, nil)
+	taskModel.On("GetByID", mock.Anything, missingID).Return(nil, gorm.ErrRecordNotFound)
+	taskModel.On("AddLabels", mock.Anything, existingID, []entities.Label{backend}).Return(nil)
+
+	labelModel := labelMock.Label{}
+	labelModel.On("FindOrCreate", mock.Anything, []string{"backend"}).Return([]entities.Label{backend}, nil)
+
+	outboxModel := outboxMock.Outbox{}
+	outboxModel.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+	// Every item runs in a transaction of its own
+	model, db := withTransactions(t, models.Model{Task: &taskModel, Label: &labelModel, Outbox: &outboxModel})
+	for _, commit := range []bool{true, true, false, true, false, false} {
+		db.ExpectBegin()
+		if commit {
+			db.ExpectCommit()
+		} else {
+			db.ExpectRollback()
+		}
+	}
+	s := &service{model: model}
+
+	atomic := false
+	req := &entities.BulkRequest{
+		Atomic: &atomic,
+		Items: []entities.BulkItem{
+			{Op: entities.BulkCreate, Task: &entities.TaskRequest{Title: "New", Status: entities.StatusPending}},
+			{Op: entities.BulkSetStatus, ID: &existingID, Status: entities.StatusCompleted},
+			{Op: entities.BulkDelete, ID: &missingID},
+			{Op: entities.BulkAddLabels, ID: &existingID, Labels: []string{"backend"}},
+			{Op: entities.BulkSetStatus, ID: &existingID, Status: "Done"},
+			{Op: entities.BulkDelete},
+		},
+	}
+
+	got, err := s.BulkTasks(context.Background(), req)
+	if err != nil {
+		t.Fatalf("service.BulkTasks() error = %v", err)
+	}
+
+	wantStatuses := []entities.BulkItemStatus{
+		entities.BulkSucceeded,
+		entities.BulkSucceeded,
+		entities.BulkFailed,
+		entities.BulkSucceeded,
+		entities.BulkFailed,
+		entities.BulkFailed,
+	}
+	for i, want := range wantStatuses {
+		if got.Results[i].Status != want {
+			t.Errorf("service.BulkTasks() item %d status = %s (%s), want %s", i, got.Results[i].Status, got.Results[i].Error, want)
+		}
+	}
+	if got.Succeeded != 3 || got.Failed != 3 {
+		t.Errorf("service.BulkTasks() succeeded = %d, failed = %d, want 3 and 3", got.Succeeded, got.Failed)
+	}
+	if got.Results[0].ID == nil {
+		t.Errorf("service.BulkTasks() created task has no id")
+	}
+	if got.Results[2].Error != entities.ErrTaskNotFound.Error() {
+		t.Errorf("service.BulkTasks() missing task error = %q", got.Results[2].Error)
+	}
+	if !strings.HasPrefix(got.Results[5].Error, entities.ErrInvalidBulk.Error()) {
+		t.Errorf("service.BulkTasks() item without id error = %q", got.Results[5].Error)
+	}
+}
+
+func Test_service_BulkTasks_atomic(t *testing.T) {
+	firstID, _ := uuid.NewV4()
+	missingID, _ := uuid.NewV4()
+	lastID, _ := uuid.NewV4()
+
+	taskModel := taskMock.Task{}
+	taskModel.On("UpdateStatus", mock.Anything, firstID, entities.StatusCompleted).Return(nil)
+	taskModel.On("GetByID", mock.Anything, firstID).Return(&entities.Task{ID: firstID}, nil)
+	taskModel.On("GetByID", mock.Anything, missingID).Return(nil, gorm.ErrRecordNotFound)
+
+	model, db := withTransactions(t, models.Model{Task: &taskModel, Outbox: &outboxMock.Outbox{}})
+	db.ExpectBegin()
+	db.ExpectRollback()
+	s := &service{model: model}
+
+	// Requests are atomic unless they opt out
+	req := &entities.BulkRequest{
+		Items: []entities.BulkItem{
+			{Op: entities.BulkSetStatus, ID: &firstID, Status: entities.StatusCompleted},
+			{Op: entities.BulkDelete, ID: &missingID},
+			{Op: entities.BulkDelete, ID: &lastID},
+		},
+	}
+
+	got, err := s.BulkTasks(context.Background(), req)
+	if err != nil {
+		t.Fatalf("service.BulkTasks() error = %v", err)
+	}
+
+	if !got.Atomic {
+		t.Error("service.BulkTasks() request without atomic was not atomic")
+	}
+	wantStatuses := []entities.BulkItemStatus{
+		entities.BulkRolledBack,
+		entities.BulkFailed,
+		entities.BulkSkipped,
+	}
+	for i, want := range wantStatuses {
+		if got.Results[i].Status != want {
+			t.Errorf("service.BulkTasks() item %d status = %s, want %s", i, got.Results[i].Status, want)
+		}
+	}
+	if got.Succeeded != 0 || got.Failed != 1 {
+		t.Errorf("service.BulkTasks() succeeded = %d, failed = %d, want 0 and 1", got.Succeeded, got.Failed)
+	}
+	// The transaction is rolled back, the outbox never written and the last
+	// task never touched
+	taskModel.AssertNotCalled(t, "GetByID", mock.Anything, lastID)
+	taskModel.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
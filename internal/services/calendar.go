@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// secretBytes is the amount of randomness in generated tokens and secrets
+const secretBytes = 32
+
+// CreateCalendarToken issues a new calendar feed token for the caller. The
+// token itself is only returned here; later it can only be revoked.
+func (s *service) CreateCalendarToken(ctx context.Context, req *entities.CalendarTokenRequest) (*entities.CalendarTokenResponse, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthorized
+	}
+
+	plain, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	token := &entities.CalendarToken{
+		UserID:    caller.UserID,
+		ProjectID: caller.ProjectID,
+		Name:      req.Name,
+		TokenHash: hashCalendarToken(plain),
+	}
+
+	// Save to database
+	if err := s.model.Calendar.Create(ctx, token); err != nil {
+		return nil, err
+	}
+
+	response := newCalendarTokenResponse(token)
+	response.Token = plain
+	return response, nil
+}
+
+// GetCalendarTokens retrieves the caller's calendar tokens
+func (s *service) GetCalendarTokens(ctx context.Context) ([]*entities.CalendarTokenResponse, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthorized
+	}
+
+	tokens, err := s.model.Calendar.GetByUser(ctx, caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*entities.CalendarTokenResponse, len(tokens))
+	for i := range tokens {
+		response[i] = newCalendarTokenResponse(&tokens[i])
+	}
+
+	return response, nil
+}
+
+// DeleteCalendarToken revokes one of the caller's calendar tokens
+func (s *service) DeleteCalendarToken(ctx context.Context, id uuid.UUID) error {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return entities.ErrUnauthorized
+	}
+
+	// Delete from database
+	return s.model.Calendar.Delete(ctx, id, caller.UserID)
+}
+
+// CalendarFeed streams the tasks with a due date visible to the token's
+// owner to fn, ordered by due date, after checking the feed token. Nothing is
+// passed to fn when the token is invalid.
+func (s *service) CalendarFeed(ctx context.Context, opts entities.CalendarFeedOptions, fn func(task *entities.TaskResponse) error) error {
+	if opts.Token == "" {
+		return entities.ErrInvalidCalendarToken
+	}
+
+	token, err := s.model.Calendar.GetByHash(ctx, hashCalendarToken(opts.Token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.ErrInvalidCalendarToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.model.Calendar.Touch(ctx, token.ID, time.Now()); err != nil {
+		return err
+	}
+
+	taskFilter := projectFilter(token.ProjectID)
+	taskFilter.Clause += " AND tasks.due_date IS NOT NULL"
+	if len(opts.Statuses) > 0 {
+		taskFilter.Clause += " AND tasks.status IN ?"
+		taskFilter.Args = append(taskFilter.Args, opts.Statuses)
+	}
+
+	// The token, not the caller, decides which projects the feed shows
+	return s.exportTasks(ctx, entities.TaskListOptions{
+		Sort:    []entities.TaskSort{{Field: "due_date"}},
+		Filter:  taskFilter,
+		Include: entities.TaskIncludes{Labels: true},
+	}, fn)
+}
+
+// newSecret returns a random URL safe token
+func newSecret() (string, error) {
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashCalendarToken returns the hash under which a calendar token is stored
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// projectFilter selects the tasks visible to a member of projectID, which
+// are those auth.Caller.CanSeeProject lets through
+func projectFilter(projectID *uuid.UUID) *entities.TaskFilter {
+	if projectID == nil {
+		return &entities.TaskFilter{Clause: "tasks.project_id IS NULL"}
+	}
+	return &entities.TaskFilter{
+		Clause: "(tasks.project_id IS NULL OR tasks.project_id = ?)",
+		Args:   []interface{}{*projectID},
+	}
+}
+
+func newCalendarTokenResponse(token *entities.CalendarToken) *entities.CalendarTokenResponse {
+	return &entities.CalendarTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		ProjectID:  token.ProjectID,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+	}
+}
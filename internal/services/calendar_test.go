@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/models"
+	calendarMock "task-management/internal/models/calendar/mocks"
+	labelMock "task-management/internal/models/label/mocks"
+	taskMock "task-management/internal/models/task/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_service_CreateCalendarToken(t *testing.T) {
+	userID, _ := uuid.NewV4()
+	ctx := auth.WithCaller(context.Background(), auth.Caller{UserID: userID})
+
+	var stored *entities.CalendarToken
+	calendarModel := calendarMock.Calendar{}
+	calendarModel.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*entities.CalendarToken)
+	}).Return(nil)
+
+	s := &service{model: models.Model{Calendar: &calendarModel}}
+
+	got, err := s.CreateCalendarToken(ctx, &entities.CalendarTokenRequest{Name: "Phone"})
+	if err != nil {
+		t.Fatalf("service.CreateCalendarToken() error = %v", err)
+	}
+	if got.Token == "" {
+		t.Fatal("service.CreateCalendarToken() returned no token")
+	}
+	if stored.UserID != userID || stored.Name != "Phone" {
+		t.Errorf("service.CreateCalendarToken() stored %+v", stored)
+	}
+	if stored.TokenHash != hashCalendarToken(got.Token) || stored.TokenHash == got.Token {
+		t.Errorf("service.CreateCalendarToken() stored hash %q for token %q", stored.TokenHash, got.Token)
+	}
+
+	if _, err := s.CreateCalendarToken(context.Background(), &entities.CalendarTokenRequest{}); !errors.Is(err, entities.ErrUnauthorized) {
+		t.Errorf("service.CreateCalendarToken() anonymous error = %v, want %v", err, entities.ErrUnauthorized)
+	}
+}
+
+func Test_service_CalendarFeed(t *testing.T) {
+	tokenID, _ := uuid.NewV4()
+	taskID, _ := uuid.NewV4()
+
+	calendarModel := calendarMock.Calendar{}
+	calendarModel.On("GetByHash", mock.Anything, hashCalendarToken("valid")).Return(&entities.CalendarToken{ID: tokenID}, nil)
+	calendarModel.On("GetByHash", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	calendarModel.On("Touch", mock.Anything, tokenID, mock.Anything).Return(nil)
+
+	taskModel := taskMock.Task{}
+	taskModel.On("Iterate", mock.Anything, mock.MatchedBy(func(opts entities.TaskListOptions) bool {
+		return opts.Filter.Clause == "tasks.project_id IS NULL AND tasks.due_date IS NOT NULL AND tasks.status IN ?" &&
+			len(opts.Sort) == 1 && opts.Sort[0].Field == "due_date"
+	}), mock.Anything).Return(func(ctx context.Context, opts entities.TaskListOptions, fn func(*entities.Task) error) error {
+		return fn(&entities.Task{ID: taskID})
+	})
+
+	labelModel := labelMock.Label{}
+	labelModel.On("GetByTaskIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID][]entities.Label{}, nil)
+
+	s := &service{model: models.Model{Task: &taskModel, Label: &labelModel, Calendar: &calendarModel}}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    int
+		wantErr error
+	}{
+		{name: "valid token", token: "valid", want: 1},
+		{name: "unknown token", token: "unknown", wantErr: entities.ErrInvalidCalendarToken},
+		{name: "missing token", token: "", wantErr: entities.ErrInvalidCalendarToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got int
+			err := s.CalendarFeed(context.Background(), entities.CalendarFeedOptions{
+				Token:    tt.token,
+				Statuses: []entities.TaskStatus{entities.StatusPending},
+			}, func(task *entities.TaskResponse) error {
+				got++
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("service.CalendarFeed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("service.CalendarFeed() streamed %d tasks, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_CalendarFeed_projects(t *testing.T) {
+	projectID, _ := uuid.NewV4()
+	otherProjectID, _ := uuid.NewV4()
+	tokenID, _ := uuid.NewV4()
+
+	tasks := []entities.Task{
+		{Title: "Own project", ProjectID: &projectID},
+		{Title: "No project"},
+		{Title: "Other project", ProjectID: &otherProjectID},
+	}
+
+	calendarModel := calendarMock.Calendar{}
+	calendarModel.On("GetByHash", mock.Anything, hashCalendarToken("valid")).Return(&entities.CalendarToken{ID: tokenID, ProjectID: &projectID}, nil)
+	calendarModel.On("Touch", mock.Anything, tokenID, mock.Anything).Return(nil)
+
+	// The model applies the filter the way Postgres would for these tasks
+	taskModel := taskMock.Task{}
+	taskModel.On("Iterate", mock.Anything, mock.MatchedBy(func(opts entities.TaskListOptions) bool {
+		return strings.HasPrefix(opts.Filter.Clause, "(tasks.project_id IS NULL OR tasks.project_id = ?) AND ") &&
+			opts.Filter.Args[0] == projectID
+	}), mock.Anything).Return(func(ctx context.Context, opts entities.TaskListOptions, fn func(*entities.Task) error) error {
+		for i := range tasks {
+			if tasks[i].ProjectID == nil || *tasks[i].ProjectID == opts.Filter.Args[0] {
+				if err := fn(&tasks[i]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	labelModel := labelMock.Label{}
+	labelModel.On("GetByTaskIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID][]entities.Label{}, nil)
+
+	s := &service{model: models.Model{Task: &taskModel, Label: &labelModel, Calendar: &calendarModel}}
+
+	var got []string
+	err := s.CalendarFeed(context.Background(), entities.CalendarFeedOptions{Token: "valid"}, func(task *entities.TaskResponse) error {
+		got = append(got, task.Title)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("service.CalendarFeed() error = %v", err)
+	}
+	if want := []string{"Own project", "No project"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("service.CalendarFeed() streamed %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"task-management/internal/entities"
+	"task-management/internal/models"
+
+	"github.com/gofrs/uuid"
+)
+
+// newTaskEvent describes a change made to a task. Deletions carry no task.
+func newTaskEvent(eventType entities.TaskEventType, task *entities.Task, previous *entities.TaskStatus) entities.TaskEvent {
+	event := entities.TaskEvent{
+		ID:             uuid.Must(uuid.NewV4()),
+		Type:           eventType,
+		OccurredAt:     time.Now().UTC(),
+		TaskID:         task.ID,
+		ProjectID:      task.ProjectID,
+		PreviousStatus: previous,
+	}
+
+	if eventType != entities.EventTaskDeleted {
+		event.Task = newTaskResponse(task)
+		if task.Labels != nil {
+			event.Task.Labels = []string{}
+		}
+		for _, label := range task.Labels {
+			event.Task.Labels = append(event.Task.Labels, label.Name)
+		}
+	}
+
+	return event
+}
+
+// newMovedAwayEvent tells the members of the project a task moved out of
+// that it is gone. The task itself is left out, they may no longer see it.
+func newMovedAwayEvent(task *entities.Task, previousProject *uuid.UUID) entities.TaskEvent {
+	event := newTaskEvent(entities.EventTaskUpdated, task, nil)
+	event.ProjectID = previousProject
+	event.Task = nil
+	return event
+}
+
+// emit saves the events to the outbox. m must be bound to the transaction
+// making the changes, so the events are published if and only if the
+// changes are committed.
+func emit(ctx context.Context, m models.Model, events ...entities.TaskEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]entities.OutboxEvent, len(events))
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		rows[i] = entities.OutboxEvent{
+			EventID:   event.ID,
+			CreatedAt: event.OccurredAt,
+			Type:      event.Type,
+			TaskID:    event.TaskID,
+			ProjectID: event.ProjectID,
+			Payload:   string(payload),
+		}
+	}
+
+	return m.Outbox.Add(ctx, rows)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"task-management/internal/entities"
+	"task-management/internal/models"
+	outboxMock "task-management/internal/models/outbox/mocks"
+	taskMock "task-management/internal/models/task/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_emit(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+
+	var saved []entities.OutboxEvent
+	outboxModel := outboxMock.Outbox{}
+	outboxModel.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).([]entities.OutboxEvent)
+	}).Return(nil)
+
+	task := &entities.Task{ID: taskID, Title: "Ship", Status: entities.StatusCompleted, Labels: []entities.Label{{Name: "backend"}}}
+	previous := entities.StatusInProgress
+	events := []entities.TaskEvent{
+		newTaskEvent(entities.EventTaskUpdated, task, nil),
+		newTaskEvent(entities.EventTaskStatusChanged, task, &previous),
+	}
+
+	if err := emit(context.Background(), models.Model{Outbox: &outboxModel}, events...); err != nil {
+		t.Fatalf("emit() error = %v", err)
+	}
+
+	if len(saved) != len(events) {
+		t.Fatalf("emit() saved %d events, want %d", len(saved), len(events))
+	}
+	for i, row := range saved {
+		if row.EventID != events[i].ID || row.Type != events[i].Type || row.TaskID != taskID {
+			t.Errorf("outbox row %d = %+v", i, row)
+		}
+	}
+
+	var event entities.TaskEvent
+	if err := json.Unmarshal([]byte(saved[1].Payload), &event); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if event.PreviousStatus == nil || *event.PreviousStatus != previous {
+		t.Errorf("payload previous status = %v", event.PreviousStatus)
+	}
+	if event.Task == nil || len(event.Task.Labels) != 1 || event.Task.Labels[0] != "backend" {
+		t.Errorf("payload task = %+v", event.Task)
+	}
+}
+
+func Test_service_DeleteTask_emitsInTransaction(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+
+	outboxModel := outboxMock.Outbox{}
+	outboxModel.On("Add", mock.Anything, mock.MatchedBy(func(rows []entities.OutboxEvent) bool {
+		return len(rows) == 1 && rows[0].Type == entities.EventTaskDeleted && rows[0].TaskID == taskID
+	})).Return(nil)
+
+	taskModel := taskMock.Task{}
+	taskModel.On("GetByID", mock.Anything, taskID).Return(&entities.Task{ID: taskID}, nil)
+	taskModel.On("Delete", mock.Anything, taskID).Return(nil)
+
+	model, db := withTransactions(t, models.Model{Task: &taskModel, Outbox: &outboxModel})
+	db.ExpectBegin()
+	db.ExpectCommit()
+	s := &service{model: model}
+
+	if err := s.DeleteTask(context.Background(), taskID); err != nil {
+		t.Fatalf("service.DeleteTask() error = %v", err)
+	}
+	outboxModel.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+
+	"task-management/internal/entities"
+	"task-management/internal/models"
+)
+
+// ImportTasks inserts validated import rows in batches within a single
+// transaction, so an import either lands completely or not at all. Dry runs
+// only report what would be imported.
+func (s *service) ImportTasks(ctx context.Context, rows []entities.ImportRow, dryRun bool) (*entities.ImportResult, error) {
+	for _, row := range rows {
+		if err := authorizeProject(ctx, row.Task.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &entities.ImportResult{DryRun: dryRun, Total: len(rows)}
+	if dryRun || len(rows) == 0 {
+		return result, nil
+	}
+
+	err := s.model.Transaction(ctx, func(tx *models.Model) error {
+		// Resolve every label once for the whole file
+		var names []string
+		for _, row := range rows {
+			names = append(names, row.Task.Labels...)
+		}
+		labels, err := tx.Label.FindOrCreate(ctx, names)
+		if err != nil {
+			return err
+		}
+		byName := make(map[string]entities.Label, len(labels))
+		for _, label := range labels {
+			byName[label.Name] = label
+		}
+
+		tasks := make([]entities.Task, len(rows))
+		for i, row := range rows {
+			tasks[i] = entities.Task{
+				Title:       row.Task.Title,
+				Description: row.Task.Description,
+				Status:      row.Task.Status,
+				DueDate:     row.Task.DueDate,
+				ProjectID:   row.Task.ProjectID,
+			}
+			for _, name := range row.Task.Labels {
+				if label, ok := byName[entities.NormalizeLabel(name)]; ok {
+					tasks[i].Labels = append(tasks[i].Labels, label)
+				}
+			}
+		}
+
+		if err := tx.Task.CreateBatch(ctx, tasks, s.cfg.ImportBatchSize); err != nil {
+			return err
+		}
+
+		events := make([]entities.TaskEvent, len(tasks))
+		for i := range tasks {
+			events[i] = newTaskEvent(entities.EventTaskCreated, &tasks[i], nil)
+		}
+		return emit(ctx, *tx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(rows)
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"task-management/internal/config"
+	"task-management/internal/entities"
+	"task-management/internal/models"
+	labelMock "task-management/internal/models/label/mocks"
+	outboxMock "task-management/internal/models/outbox/mocks"
+	taskMock "task-management/internal/models/task/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_service_ImportTasks(t *testing.T) {
+	cfg := config.Service{ImportBatchSize: 500}
+	labelID, _ := uuid.NewV4()
+	backend := entities.Label{ID: labelID, Name: "backend"}
+
+	rows := []entities.ImportRow{
+		{Row: 1, Task: entities.TaskRequest{Title: "First", Status: entities.StatusPending, Labels: []string{"Backend"}}},
+		{Row: 2, Task: entities.TaskRequest{Title: "Second", Status: entities.StatusInProgress}},
+	}
+
+	// The labels of every row are resolved at once and attached by name
+	withLabels := mock.MatchedBy(func(tasks []entities.Task) bool {
+		return len(tasks) == 2 &&
+			tasks[0].Title == "First" && reflect.DeepEqual(tasks[0].Labels, []entities.Label{backend}) &&
+			tasks[1].Title == "Second" && tasks[1].Labels == nil
+	})
+
+	successTask := taskMock.Task{}
+	successTask.On("CreateBatch", mock.Anything, withLabels, cfg.ImportBatchSize).Return(nil)
+
+	// A row breaking a constraint fails its batch, and the import with it
+	failingTask := taskMock.Task{}
+	failingTask.On("CreateBatch", mock.Anything, mock.Anything, cfg.ImportBatchSize).Return(errors.New("value too long for type character varying(255)"))
+
+	successLabel := labelMock.Label{}
+	successLabel.On("FindOrCreate", mock.Anything, []string{"Backend"}).Return([]entities.Label{backend}, nil)
+
+	errorLabel := labelMock.Label{}
+	errorLabel.On("FindOrCreate", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+	successOutbox := outboxMock.Outbox{}
+	successOutbox.On("Add", mock.Anything, mock.MatchedBy(func(events []entities.OutboxEvent) bool {
+		return len(events) == 2
+	})).Return(nil)
+
+	errorOutbox := outboxMock.Outbox{}
+	errorOutbox.On("Add", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	tests := []struct {
+		name    string
+		model   models.Model
+		tx      string
+		rows    []entities.ImportRow
+		dryRun  bool
+		want    *entities.ImportResult
+		wantErr bool
+	}{
+		{
+			name:   "dry run",
+			model:  models.Model{Task: &taskMock.Task{}, Label: &labelMock.Label{}},
+			rows:   rows,
+			dryRun: true,
+			want:   &entities.ImportResult{DryRun: true, Total: 2},
+		},
+		{
+			name:  "no rows",
+			model: models.Model{Task: &taskMock.Task{}, Label: &labelMock.Label{}},
+			want:  &entities.ImportResult{},
+		},
+		{
+			name:  "successful import",
+			model: models.Model{Task: &successTask, Label: &successLabel, Outbox: &successOutbox},
+			tx:    "commit",
+			rows:  rows,
+			want:  &entities.ImportResult{Total: 2, Imported: 2},
+		},
+		{
+			name:    "label lookup error",
+			model:   models.Model{Task: &taskMock.Task{}, Label: &errorLabel},
+			tx:      "rollback",
+			rows:    rows,
+			wantErr: true,
+		},
+		{
+			name:    "failing row",
+			model:   models.Model{Task: &failingTask, Label: &successLabel},
+			tx:      "rollback",
+			rows:    rows,
+			wantErr: true,
+		},
+		{
+			name:    "events not recorded after the insert",
+			model:   models.Model{Task: &successTask, Label: &successLabel, Outbox: &errorOutbox},
+			tx:      "rollback",
+			rows:    rows,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Nothing is imported unless the transaction commits
+			model, db := withTransactions(t, tt.model)
+			switch tt.tx {
+			case "commit":
+				db.ExpectBegin()
+				db.ExpectCommit()
+			case "rollback":
+				db.ExpectBegin()
+				db.ExpectRollback()
+			}
+
+			s := &service{model: model, cfg: cfg}
+			got, err := s.ImportTasks(context.Background(), tt.rows, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("service.ImportTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service.ImportTasks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,17 +16,185 @@ type Service struct {
 	mock.Mock
 }
 
+// BulkTasks provides a mock function with given fields: ctx, req
+func (_m *Service) BulkTasks(ctx context.Context, req *entities.BulkRequest) (*entities.BulkResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkTasks")
+	}
+
+	var r0 *entities.BulkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.BulkRequest) (*entities.BulkResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.BulkRequest) *entities.BulkResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.BulkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.BulkRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CalendarFeed provides a mock function with given fields: ctx, opts, fn
+func (_m *Service) CalendarFeed(ctx context.Context, opts entities.CalendarFeedOptions, fn func(*entities.TaskResponse) error) error {
+	ret := _m.Called(ctx, opts, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CalendarFeed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.CalendarFeedOptions, func(*entities.TaskResponse) error) error); ok {
+		r0 = rf(ctx, opts, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCalendarToken provides a mock function with given fields: ctx, req
+func (_m *Service) CreateCalendarToken(ctx context.Context, req *entities.CalendarTokenRequest) (*entities.CalendarTokenResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCalendarToken")
+	}
+
+	var r0 *entities.CalendarTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.CalendarTokenRequest) (*entities.CalendarTokenResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.CalendarTokenRequest) *entities.CalendarTokenResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.CalendarTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.CalendarTokenRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTask provides a mock function with given fields: ctx, req
-func (_m *Service) CreateTask(ctx context.Context, req *entities.TaskRequest) error {
+func (_m *Service) CreateTask(ctx context.Context, req *entities.TaskRequest) (*entities.TaskResponse, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTask")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entities.TaskRequest) error); ok {
+	var r0 *entities.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.TaskRequest) (*entities.TaskResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.TaskRequest) *entities.TaskResponse); ok {
 		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.TaskRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateView provides a mock function with given fields: ctx, req
+func (_m *Service) CreateView(ctx context.Context, req *entities.ViewRequest) (*entities.ViewResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateView")
+	}
+
+	var r0 *entities.ViewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.ViewRequest) (*entities.ViewResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.ViewRequest) *entities.ViewResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ViewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.ViewRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, req
+func (_m *Service) CreateWebhook(ctx context.Context, req *entities.WebhookRequest) (*entities.WebhookResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *entities.WebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.WebhookRequest) (*entities.WebhookResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.WebhookRequest) *entities.WebhookResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.WebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.WebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCalendarToken provides a mock function with given fields: ctx, id
+func (_m *Service) DeleteCalendarToken(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCalendarToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,9 +220,63 @@ func (_m *Service) DeleteTask(ctx context.Context, id uuid.UUID) error {
 	return r0
 }
 
-// GetAllTasks provides a mock function with given fields: ctx, page, pageSize, status
-func (_m *Service) GetAllTasks(ctx context.Context, page int, pageSize int, status *entities.TaskStatus) ([]*entities.TaskResponse, error) {
-	ret := _m.Called(ctx, page, pageSize, status)
+// DeleteView provides a mock function with given fields: ctx, id
+func (_m *Service) DeleteView(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteView")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *Service) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportTasks provides a mock function with given fields: ctx, opts, fn
+func (_m *Service) ExportTasks(ctx context.Context, opts entities.TaskListOptions, fn func(*entities.TaskResponse) error) error {
+	ret := _m.Called(ctx, opts, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskListOptions, func(*entities.TaskResponse) error) error); ok {
+		r0 = rf(ctx, opts, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllTasks provides a mock function with given fields: ctx, opts
+func (_m *Service) GetAllTasks(ctx context.Context, opts entities.TaskListOptions) ([]*entities.TaskResponse, error) {
+	ret := _m.Called(ctx, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllTasks")
@@ -62,19 +284,19 @@ func (_m *Service) GetAllTasks(ctx context.Context, page int, pageSize int, stat
 
 	var r0 []*entities.TaskResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *entities.TaskStatus) ([]*entities.TaskResponse, error)); ok {
-		return rf(ctx, page, pageSize, status)
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskListOptions) ([]*entities.TaskResponse, error)); ok {
+		return rf(ctx, opts)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, *entities.TaskStatus) []*entities.TaskResponse); ok {
-		r0 = rf(ctx, page, pageSize, status)
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskListOptions) []*entities.TaskResponse); ok {
+		r0 = rf(ctx, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.TaskResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, *entities.TaskStatus) error); ok {
-		r1 = rf(ctx, page, pageSize, status)
+	if rf, ok := ret.Get(1).(func(context.Context, entities.TaskListOptions) error); ok {
+		r1 = rf(ctx, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -82,9 +304,39 @@ func (_m *Service) GetAllTasks(ctx context.Context, page int, pageSize int, stat
 	return r0, r1
 }
 
-// GetTaskByID provides a mock function with given fields: ctx, id
-func (_m *Service) GetTaskByID(ctx context.Context, id uuid.UUID) (*entities.TaskResponse, error) {
-	ret := _m.Called(ctx, id)
+// GetCalendarTokens provides a mock function with given fields: ctx
+func (_m *Service) GetCalendarTokens(ctx context.Context) ([]*entities.CalendarTokenResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendarTokens")
+	}
+
+	var r0 []*entities.CalendarTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entities.CalendarTokenResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entities.CalendarTokenResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.CalendarTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskByID provides a mock function with given fields: ctx, id, include
+func (_m *Service) GetTaskByID(ctx context.Context, id uuid.UUID, include entities.TaskIncludes) (*entities.TaskResponse, error) {
+	ret := _m.Called(ctx, id, include)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTaskByID")
@@ -92,14 +344,134 @@ func (_m *Service) GetTaskByID(ctx context.Context, id uuid.UUID) (*entities.Tas
 
 	var r0 *entities.TaskResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entities.TaskResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entities.TaskIncludes) (*entities.TaskResponse, error)); ok {
+		return rf(ctx, id, include)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entities.TaskIncludes) *entities.TaskResponse); ok {
+		r0 = rf(ctx, id, include)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entities.TaskIncludes) error); ok {
+		r1 = rf(ctx, id, include)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskLabels provides a mock function with given fields: ctx, taskIDs
+func (_m *Service) GetTaskLabels(ctx context.Context, taskIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	ret := _m.Called(ctx, taskIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskLabels")
+	}
+
+	var r0 map[uuid.UUID][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) (map[uuid.UUID][]string, error)); ok {
+		return rf(ctx, taskIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) map[uuid.UUID][]string); ok {
+		r0 = rf(ctx, taskIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, taskIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetViewByID provides a mock function with given fields: ctx, id
+func (_m *Service) GetViewByID(ctx context.Context, id uuid.UUID) (*entities.ViewResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetViewByID")
+	}
+
+	var r0 *entities.ViewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entities.ViewResponse, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entities.TaskResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entities.ViewResponse); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.TaskResponse)
+			r0 = ret.Get(0).(*entities.ViewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetViews provides a mock function with given fields: ctx
+func (_m *Service) GetViews(ctx context.Context) ([]*entities.ViewResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetViews")
+	}
+
+	var r0 []*entities.ViewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entities.ViewResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entities.ViewResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ViewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookByID provides a mock function with given fields: ctx, id
+func (_m *Service) GetWebhookByID(ctx context.Context, id uuid.UUID) (*entities.WebhookResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookByID")
+	}
+
+	var r0 *entities.WebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entities.WebhookResponse, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entities.WebhookResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.WebhookResponse)
 		}
 	}
 
@@ -112,6 +484,126 @@ func (_m *Service) GetTaskByID(ctx context.Context, id uuid.UUID) (*entities.Tas
 	return r0, r1
 }
 
+// GetWebhookDeliveries provides a mock function with given fields: ctx, id, page, pageSize
+func (_m *Service) GetWebhookDeliveries(ctx context.Context, id uuid.UUID, page int, pageSize int) ([]*entities.WebhookDeliveryResponse, error) {
+	ret := _m.Called(ctx, id, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDeliveries")
+	}
+
+	var r0 []*entities.WebhookDeliveryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) ([]*entities.WebhookDeliveryResponse, error)); ok {
+		return rf(ctx, id, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) []*entities.WebhookDeliveryResponse); ok {
+		r0 = rf(ctx, id, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.WebhookDeliveryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r1 = rf(ctx, id, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: ctx
+func (_m *Service) GetWebhooks(ctx context.Context) ([]*entities.WebhookResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooks")
+	}
+
+	var r0 []*entities.WebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*entities.WebhookResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*entities.WebhookResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.WebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportTasks provides a mock function with given fields: ctx, rows, dryRun
+func (_m *Service) ImportTasks(ctx context.Context, rows []entities.ImportRow, dryRun bool) (*entities.ImportResult, error) {
+	ret := _m.Called(ctx, rows, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTasks")
+	}
+
+	var r0 *entities.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.ImportRow, bool) (*entities.ImportResult, error)); ok {
+		return rf(ctx, rows, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.ImportRow, bool) *entities.ImportResult); ok {
+		r0 = rf(ctx, rows, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entities.ImportRow, bool) error); ok {
+		r1 = rf(ctx, rows, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchTasks provides a mock function with given fields: ctx, opts
+func (_m *Service) SearchTasks(ctx context.Context, opts entities.TaskSearchOptions) ([]*entities.TaskSearchResponse, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTasks")
+	}
+
+	var r0 []*entities.TaskSearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskSearchOptions) ([]*entities.TaskSearchResponse, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entities.TaskSearchOptions) []*entities.TaskSearchResponse); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.TaskSearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entities.TaskSearchOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateTask provides a mock function with given fields: ctx, id, req
 func (_m *Service) UpdateTask(ctx context.Context, id uuid.UUID, req *entities.TaskRequest) error {
 	ret := _m.Called(ctx, id, req)
@@ -130,6 +622,42 @@ func (_m *Service) UpdateTask(ctx context.Context, id uuid.UUID, req *entities.T
 	return r0
 }
 
+// UpdateView provides a mock function with given fields: ctx, id, req
+func (_m *Service) UpdateView(ctx context.Context, id uuid.UUID, req *entities.ViewRequest) error {
+	ret := _m.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateView")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *entities.ViewRequest) error); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, id, req
+func (_m *Service) UpdateWebhook(ctx context.Context, id uuid.UUID, req *entities.WebhookRequest) error {
+	ret := _m.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *entities.WebhookRequest) error); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
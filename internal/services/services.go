@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"task-management/internal/config"
 	"task-management/internal/entities"
 	"task-management/internal/models"
 
@@ -13,20 +14,47 @@ import (
 // all the services from all service packages
 type service struct {
 	model models.Model
+	cfg   config.Service
 }
 
 // New creates a new instance of Service
-func New(model *models.Model) Service {
-	m := &service{model: *model}
+func New(model *models.Model, cfg config.Service) Service {
+	m := &service{model: *model, cfg: cfg}
 	return m
 }
 
 type Service interface {
 
 	// Task services
-	CreateTask(ctx context.Context, req *entities.TaskRequest) error
-	GetTaskByID(ctx context.Context, id uuid.UUID) (*entities.TaskResponse, error)
-	GetAllTasks(ctx context.Context, page, pageSize int, status *entities.TaskStatus) ([]*entities.TaskResponse, error)
+	CreateTask(ctx context.Context, req *entities.TaskRequest) (*entities.TaskResponse, error)
+	GetTaskByID(ctx context.Context, id uuid.UUID, include entities.TaskIncludes) (*entities.TaskResponse, error)
+	GetAllTasks(ctx context.Context, opts entities.TaskListOptions) ([]*entities.TaskResponse, error)
+	ExportTasks(ctx context.Context, opts entities.TaskListOptions, fn func(task *entities.TaskResponse) error) error
+	SearchTasks(ctx context.Context, opts entities.TaskSearchOptions) ([]*entities.TaskSearchResponse, error)
+	GetTaskLabels(ctx context.Context, taskIDs []uuid.UUID) (map[uuid.UUID][]string, error)
 	UpdateTask(ctx context.Context, id uuid.UUID, req *entities.TaskRequest) error
 	DeleteTask(ctx context.Context, id uuid.UUID) error
+	BulkTasks(ctx context.Context, req *entities.BulkRequest) (*entities.BulkResponse, error)
+	ImportTasks(ctx context.Context, rows []entities.ImportRow, dryRun bool) (*entities.ImportResult, error)
+
+	// View services
+	CreateView(ctx context.Context, req *entities.ViewRequest) (*entities.ViewResponse, error)
+	GetViewByID(ctx context.Context, id uuid.UUID) (*entities.ViewResponse, error)
+	GetViews(ctx context.Context) ([]*entities.ViewResponse, error)
+	UpdateView(ctx context.Context, id uuid.UUID, req *entities.ViewRequest) error
+	DeleteView(ctx context.Context, id uuid.UUID) error
+
+	// Calendar services
+	CreateCalendarToken(ctx context.Context, req *entities.CalendarTokenRequest) (*entities.CalendarTokenResponse, error)
+	GetCalendarTokens(ctx context.Context) ([]*entities.CalendarTokenResponse, error)
+	DeleteCalendarToken(ctx context.Context, id uuid.UUID) error
+	CalendarFeed(ctx context.Context, opts entities.CalendarFeedOptions, fn func(task *entities.TaskResponse) error) error
+
+	// Webhook services
+	CreateWebhook(ctx context.Context, req *entities.WebhookRequest) (*entities.WebhookResponse, error)
+	GetWebhooks(ctx context.Context) ([]*entities.WebhookResponse, error)
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (*entities.WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, req *entities.WebhookRequest) error
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	GetWebhookDeliveries(ctx context.Context, id uuid.UUID, page, pageSize int) ([]*entities.WebhookDeliveryResponse, error)
 }
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"task-management/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// withTransactions returns m running its transactions on a sqlmock
+// database, along with the mock on which to expect their commits and
+// rollbacks. Unmet expectations fail the test.
+func withTransactions(t *testing.T, m models.Model) (models.Model, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, db, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := db.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	return *m.WithDB(gdb), db
+}
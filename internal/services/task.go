@@ -3,100 +3,341 @@ package services
 import (
 	"context"
 
+	"task-management/internal/auth"
 	"task-management/internal/entities"
+	"task-management/internal/logging"
+	"task-management/internal/models"
 
 	"github.com/gofrs/uuid"
 )
 
-// CreateTask adds a new task
-func (s *service) CreateTask(ctx context.Context, req *entities.TaskRequest) error {
+// CreateTask adds a new task and returns it
+func (s *service) CreateTask(ctx context.Context, req *entities.TaskRequest) (*entities.TaskResponse, error) {
+	if err := authorizeProject(ctx, req.ProjectID); err != nil {
+		return nil, err
+	}
+
 	// Create task entity from request
 	task := &entities.Task{
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      req.Status,
 		DueDate:     req.DueDate,
+		ProjectID:   req.ProjectID,
 	}
 
-	// Save to database
-	err := s.model.Task.Create(ctx, task)
+	err := s.model.Transaction(ctx, func(tx *models.Model) error {
+		if len(req.Labels) > 0 {
+			labels, err := tx.Label.FindOrCreate(ctx, req.Labels)
+			if err != nil {
+				return err
+			}
+			task.Labels = labels
+		}
+
+		// Save to database
+		if err := tx.Task.Create(ctx, task); err != nil {
+			return err
+		}
+
+		return emit(ctx, *tx, newTaskEvent(entities.EventTaskCreated, task, nil))
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	logging.FromContext(ctx).Info("task created", "task_id", task.ID, "status", task.Status)
 
-	return nil
+	response := newTaskResponse(task)
+	response.Labels = []string{}
+	for _, label := range task.Labels {
+		response.Labels = append(response.Labels, label.Name)
+	}
+
+	return response, nil
 }
 
-// GetTaskByID retrieves a task by its ID
-func (s *service) GetTaskByID(ctx context.Context, id uuid.UUID) (*entities.TaskResponse, error) {
+// GetTaskByID retrieves a task by its ID along with the requested relations.
+// Tasks of projects the caller cannot see are not found.
+func (s *service) GetTaskByID(ctx context.Context, id uuid.UUID, include entities.TaskIncludes) (*entities.TaskResponse, error) {
 	// Get task from database
 	task, err := s.model.Task.GetByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if caller, _ := auth.FromContext(ctx); !caller.CanSeeProject(task.ProjectID) {
+		return nil, entities.ErrTaskNotFound
+	}
+
+	response := []*entities.TaskResponse{newTaskResponse(task)}
+	if err := s.includeRelations(ctx, response, include); err != nil {
+		return nil, err
+	}
+
+	return response[0], nil
+}
+
+// GetAllTasks retrieves the tasks visible to the caller with pagination,
+// optional status filtering and ordering
+func (s *service) GetAllTasks(ctx context.Context, opts entities.TaskListOptions) ([]*entities.TaskResponse, error) {
+	opts.Filter = visibleTasks(ctx, opts.Filter)
+
+	// Get tasks from database with pagination, filtering and ordering
+	tasks, err := s.model.Task.GetAll(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	// Convert to response objects
+	response := make([]*entities.TaskResponse, len(tasks))
+	for i := range tasks {
+		response[i] = newTaskResponse(&tasks[i])
+	}
+
+	if err := s.includeRelations(ctx, response, opts.Include); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ExportTasks streams every task visible to the caller and matching opts to
+// fn along with the requested relations
+func (s *service) ExportTasks(ctx context.Context, opts entities.TaskListOptions, fn func(task *entities.TaskResponse) error) error {
+	opts.Filter = visibleTasks(ctx, opts.Filter)
+	return s.exportTasks(ctx, opts, fn)
+}
+
+// exportTasks streams every task matching opts to fn, whoever the caller is.
+// Only one chunk of tasks is held in memory at a time, their relations being
+// loaded together.
+func (s *service) exportTasks(ctx context.Context, opts entities.TaskListOptions, fn func(task *entities.TaskResponse) error) error {
+	chunk := make([]*entities.TaskResponse, 0, s.cfg.ExportChunkSize)
+
+	flush := func() error {
+		if err := s.includeRelations(ctx, chunk, opts.Include); err != nil {
+			return err
+		}
+		for _, task := range chunk {
+			if err := fn(task); err != nil {
+				return err
+			}
+		}
+		chunk = chunk[:0]
+		return nil
+	}
+
+	err := s.model.Task.Iterate(ctx, opts, func(task *entities.Task) error {
+		chunk = append(chunk, newTaskResponse(task))
+		if len(chunk) < s.cfg.ExportChunkSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// includeRelations embeds the requested relations into the responses. Each
+// relation is loaded with a single query for the whole page.
+func (s *service) includeRelations(ctx context.Context, tasks []*entities.TaskResponse, include entities.TaskIncludes) error {
+	if !include.Labels || len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	labels, err := s.GetTaskLabels(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		task.Labels = labels[task.ID]
+	}
+
+	return nil
+}
+
+// GetTaskLabels returns the label names of each of the given tasks with a
+// single query. Every task has an entry, empty when it has no labels.
+func (s *service) GetTaskLabels(ctx context.Context, taskIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	labels, err := s.model.Label.GetByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uuid.UUID][]string, len(taskIDs))
+	for _, id := range taskIDs {
+		names[id] = []string{}
+		for _, label := range labels[id] {
+			names[id] = append(names[id], label.Name)
+		}
+	}
+
+	return names, nil
+}
+
+func newTaskResponse(task *entities.Task) *entities.TaskResponse {
 	return &entities.TaskResponse{
 		ID:          task.ID,
 		Title:       task.Title,
 		Description: task.Description,
 		Status:      task.Status,
 		DueDate:     task.DueDate,
+		ProjectID:   task.ProjectID,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
-	}, nil
+	}
 }
 
-// GetAllTasks retrieves all tasks with pagination and optional status filtering
-func (s *service) GetAllTasks(ctx context.Context, page, pageSize int, status *entities.TaskStatus) ([]*entities.TaskResponse, error) {
-	// Get tasks from database with pagination and filtering
-	tasks, err := s.model.Task.GetAll(ctx, page, pageSize, status)
+// SearchTasks runs a full-text search over the tasks visible to the caller
+// with optional status filtering
+func (s *service) SearchTasks(ctx context.Context, opts entities.TaskSearchOptions) ([]*entities.TaskSearchResponse, error) {
+	opts.Filter = visibleTasks(ctx, opts.Filter)
+
+	hits, err := s.model.Task.Search(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to response objects
-	response := make([]*entities.TaskResponse, len(tasks))
-	for i, task := range tasks {
-		response[i] = &entities.TaskResponse{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-			DueDate:     task.DueDate,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   task.UpdatedAt,
+	response := make([]*entities.TaskSearchResponse, len(hits))
+	for i, hit := range hits {
+		response[i] = &entities.TaskSearchResponse{
+			TaskResponse: *newTaskResponse(&hit.Task),
+			Rank:         hit.Rank,
+			Highlights: entities.TaskHighlights{
+				Title:       hit.TitleHighlight,
+				Description: hit.DescriptionHighlight,
+			},
 		}
 	}
 
 	return response, nil
 }
 
-// UpdateTask updates an existing task
+// UpdateTask updates an existing task. A task stays in its project unless
+// the request names another one the caller can see.
 func (s *service) UpdateTask(ctx context.Context, id uuid.UUID, req *entities.TaskRequest) error {
 	// Check if task exists
 	existingTask, err := s.model.Task.GetByID(ctx, id)
 	if err != nil {
+		return notFound(err)
+	}
+	if err := authorizeProject(ctx, existingTask.ProjectID); err != nil {
 		return err
 	}
 
+	previousStatus := existingTask.Status
+	previousProject := existingTask.ProjectID
+
 	// Update fields
 	existingTask.Title = req.Title
 	existingTask.Description = req.Description
 	existingTask.Status = req.Status
 	existingTask.DueDate = req.DueDate
+	if req.ProjectID != nil {
+		if err := authorizeProject(ctx, req.ProjectID); err != nil {
+			return err
+		}
+		existingTask.ProjectID = req.ProjectID
+	}
+	// Labels are only replaced when the request carries them
+	existingTask.Labels = nil
+
+	err = s.model.Transaction(ctx, func(tx *models.Model) error {
+		switch {
+		case len(req.Labels) > 0:
+			labels, err := tx.Label.FindOrCreate(ctx, req.Labels)
+			if err != nil {
+				return err
+			}
+			existingTask.Labels = labels
+		case req.Labels != nil:
+			existingTask.Labels = []entities.Label{}
+		}
+
+		// Save to database
+		if err := tx.Task.Update(ctx, existingTask); err != nil {
+			return err
+		}
+
+		events := []entities.TaskEvent{newTaskEvent(entities.EventTaskUpdated, existingTask, nil)}
+		if existingTask.Status != previousStatus {
+			events = append(events, newTaskEvent(entities.EventTaskStatusChanged, existingTask, &previousStatus))
+		}
+		if !sameProject(previousProject, existingTask.ProjectID) {
+			events = append(events, newMovedAwayEvent(existingTask, previousProject))
+		}
+		return emit(ctx, *tx, events...)
+	})
+	if err != nil {
+		return err
+	}
 
-	// Save to database
-	return s.model.Task.Update(ctx, existingTask)
+	log := logging.FromContext(ctx).With("task_id", id, "status", existingTask.Status)
+	if existingTask.Status != previousStatus {
+		log = log.With("previous_status", previousStatus)
+	}
+	log.Info("task updated")
+	return nil
 }
 
 // DeleteTask removes a task by its ID
 func (s *service) DeleteTask(ctx context.Context, id uuid.UUID) error {
 	// Check if task exists
-	_, err := s.model.Task.GetByID(ctx, id)
+	task, err := s.model.Task.GetByID(ctx, id)
 	if err != nil {
+		return notFound(err)
+	}
+	if err := authorizeProject(ctx, task.ProjectID); err != nil {
 		return err
 	}
 
-	// Delete from database
-	return s.model.Task.Delete(ctx, id)
+	err = s.model.Transaction(ctx, func(tx *models.Model) error {
+		// Delete from database
+		if err := tx.Task.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return emit(ctx, *tx, newTaskEvent(entities.EventTaskDeleted, task, nil))
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("task deleted", "task_id", id)
+	return nil
+}
+
+// authorizeProject checks that the caller may write the tasks of projectID.
+// Callers see a single project, anonymous ones none, and every caller writes
+// the tasks without a project.
+func authorizeProject(ctx context.Context, projectID *uuid.UUID) error {
+	caller, _ := auth.FromContext(ctx)
+	if !caller.CanSeeProject(projectID) {
+		return entities.ErrForbidden
+	}
+	return nil
+}
+
+// visibleTasks restricts f to the tasks of the projects the caller can see,
+// the reading counterpart of authorizeProject
+func visibleTasks(ctx context.Context, f *entities.TaskFilter) *entities.TaskFilter {
+	caller, _ := auth.FromContext(ctx)
+	scoped := projectFilter(caller.ProjectID)
+	if f != nil {
+		scoped.Clause += " AND (" + f.Clause + ")"
+		scoped.Args = append(scoped.Args, f.Args...)
+	}
+	return scoped
+}
+
+func sameProject(a, b *uuid.UUID) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
 }
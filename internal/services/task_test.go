@@ -2,13 +2,19 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"task-management/internal/auth"
+	"task-management/internal/config"
 	"task-management/internal/entities"
 	"task-management/internal/models"
+	labelMock "task-management/internal/models/label/mocks"
+	outboxMock "task-management/internal/models/outbox/mocks"
 	taskMock "task-management/internal/models/task/mocks"
 
 	"github.com/gofrs/uuid"
@@ -41,6 +47,17 @@ func Test_service_CreateTask(t *testing.T) {
 	errorMock := taskMock.Task{}
 	errorMock.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
 
+	outboxModel := outboxMock.Outbox{}
+	outboxModel.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+	successModel, successDB := withTransactions(t, models.Model{Task: &successMock, Outbox: &outboxModel})
+	successDB.ExpectBegin()
+	successDB.ExpectCommit()
+
+	errorModel, errorDB := withTransactions(t, models.Model{Task: &errorMock})
+	errorDB.ExpectBegin()
+	errorDB.ExpectRollback()
+
 	tests := []struct {
 		name    string
 		s       *service
@@ -48,22 +65,14 @@ func Test_service_CreateTask(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "successful creation",
-			s: &service{
-				model: models.Model{
-					Task: &successMock,
-				},
-			},
+			name:    "successful creation",
+			s:       &service{model: successModel},
 			req:     req,
 			wantErr: false,
 		},
 		{
-			name: "database error",
-			s: &service{
-				model: models.Model{
-					Task: &errorMock,
-				},
-			},
+			name:    "database error",
+			s:       &service{model: errorModel},
 			req:     req,
 			wantErr: true,
 		},
@@ -71,13 +80,204 @@ func Test_service_CreateTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.s.CreateTask(context.Background(), tt.req); (err != nil) != tt.wantErr {
+			if _, err := tt.s.CreateTask(context.Background(), tt.req); (err != nil) != tt.wantErr {
 				t.Errorf("service.CreateTask() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func Test_service_CreateTask_labels(t *testing.T) {
+	labelID, _ := uuid.NewV4()
+	backend := entities.Label{ID: labelID, Name: "backend"}
+
+	req := &entities.TaskRequest{
+		Title:  "Test Task",
+		Status: entities.StatusPending,
+		Labels: []string{"Backend", "backend"},
+	}
+
+	successTask := taskMock.Task{}
+	successTask.On("Create", mock.Anything, mock.MatchedBy(func(t *entities.Task) bool {
+		return reflect.DeepEqual(t.Labels, []entities.Label{backend})
+	})).Return(nil)
+
+	successLabel := labelMock.Label{}
+	successLabel.On("FindOrCreate", mock.Anything, req.Labels).Return([]entities.Label{backend}, nil)
+
+	errorLabel := labelMock.Label{}
+	errorLabel.On("FindOrCreate", mock.Anything, req.Labels).Return(nil, errors.New("db error"))
+
+	outboxModel := outboxMock.Outbox{}
+	outboxModel.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+	successModel, successDB := withTransactions(t, models.Model{Task: &successTask, Label: &successLabel, Outbox: &outboxModel})
+	successDB.ExpectBegin()
+	successDB.ExpectCommit()
+
+	errorModel, errorDB := withTransactions(t, models.Model{Task: &taskMock.Task{}, Label: &errorLabel})
+	errorDB.ExpectBegin()
+	errorDB.ExpectRollback()
+
+	tests := []struct {
+		name    string
+		s       *service
+		wantErr bool
+	}{
+		{
+			name:    "labels are resolved before creation",
+			s:       &service{model: successModel},
+			wantErr: false,
+		},
+		{
+			name:    "label lookup error",
+			s:       &service{model: errorModel},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.s.CreateTask(context.Background(), req); (err != nil) != tt.wantErr {
+				t.Errorf("service.CreateTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_UpdateTask_labels(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+	labelID, _ := uuid.NewV4()
+	backend := entities.Label{ID: labelID, Name: "backend"}
+
+	tests := []struct {
+		name       string
+		labels     []string
+		wantLabels []entities.Label
+	}{
+		{"omitted labels are kept", nil, nil},
+		{"empty labels are cleared", []string{}, []entities.Label{}},
+		{"given labels replace the others", []string{"backend"}, []entities.Label{backend}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskModel := taskMock.Task{}
+			taskModel.On("GetByID", mock.Anything, taskID).Return(&entities.Task{
+				ID:     taskID,
+				Title:  "Test Task",
+				Status: entities.StatusPending,
+				Labels: []entities.Label{{Name: "frontend"}},
+			}, nil)
+			taskModel.On("Update", mock.Anything, mock.MatchedBy(func(t *entities.Task) bool {
+				return reflect.DeepEqual(t.Labels, tt.wantLabels)
+			})).Return(nil)
+
+			labelModel := labelMock.Label{}
+			labelModel.On("FindOrCreate", mock.Anything, []string{"backend"}).Return([]entities.Label{backend}, nil)
+
+			outboxModel := outboxMock.Outbox{}
+			outboxModel.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			model, db := withTransactions(t, models.Model{Task: &taskModel, Label: &labelModel, Outbox: &outboxModel})
+			db.ExpectBegin()
+			db.ExpectCommit()
+
+			s := &service{model: model}
+			req := &entities.TaskRequest{Title: "Test Task", Status: entities.StatusPending, Labels: tt.labels}
+			if err := s.UpdateTask(context.Background(), taskID, req); err != nil {
+				t.Fatalf("service.UpdateTask() error = %v", err)
+			}
+			taskModel.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_service_CreateTask_forbiddenProject(t *testing.T) {
+	projectID, _ := uuid.NewV4()
+	otherProjectID, _ := uuid.NewV4()
+	ctx := auth.WithCaller(context.Background(), auth.Caller{ProjectID: &projectID})
+
+	s := &service{model: models.Model{Task: &taskMock.Task{}}}
+
+	_, err := s.CreateTask(ctx, &entities.TaskRequest{Title: "Test Task", Status: entities.StatusPending, ProjectID: &otherProjectID})
+	if !errors.Is(err, entities.ErrForbidden) {
+		t.Errorf("service.CreateTask() error = %v, want %v", err, entities.ErrForbidden)
+	}
+}
+
+func Test_service_UpdateTask_projects(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+	projectID, _ := uuid.NewV4()
+	otherProjectID, _ := uuid.NewV4()
+	ctx := auth.WithCaller(context.Background(), auth.Caller{ProjectID: &projectID})
+
+	tests := []struct {
+		name        string
+		current     *uuid.UUID
+		requested   *uuid.UUID
+		wantProject *uuid.UUID
+		// wantRouted are the projects of the events emitted
+		wantRouted []*uuid.UUID
+		wantErr    error
+	}{
+		{name: "omitted project is kept", current: &projectID, wantProject: &projectID, wantRouted: []*uuid.UUID{&projectID}},
+		{name: "moved into the caller's project", requested: &projectID, wantProject: &projectID, wantRouted: []*uuid.UUID{&projectID, nil}},
+		{name: "moved into another project", current: &projectID, requested: &otherProjectID, wantErr: entities.ErrForbidden},
+		{name: "task of another project", current: &otherProjectID, wantErr: entities.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *entities.Task
+			taskModel := taskMock.Task{}
+			taskModel.On("GetByID", mock.Anything, taskID).Return(&entities.Task{ID: taskID, Title: "Test Task", Status: entities.StatusPending, ProjectID: tt.current}, nil)
+			taskModel.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*entities.Task)
+			}).Return(nil)
+
+			var emitted []entities.OutboxEvent
+			outboxModel := outboxMock.Outbox{}
+			outboxModel.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				emitted = args.Get(1).([]entities.OutboxEvent)
+			}).Return(nil)
+
+			model, db := withTransactions(t, models.Model{Task: &taskModel, Outbox: &outboxModel})
+			if tt.wantErr == nil {
+				db.ExpectBegin()
+				db.ExpectCommit()
+			}
+
+			s := &service{model: model}
+			req := &entities.TaskRequest{Title: "Test Task", Status: entities.StatusPending, ProjectID: tt.requested}
+			err := s.UpdateTask(ctx, taskID, req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("service.UpdateTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				taskModel.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+
+			if !sameProject(saved.ProjectID, tt.wantProject) {
+				t.Errorf("service.UpdateTask() saved project %v, want %v", saved.ProjectID, tt.wantProject)
+			}
+			if len(emitted) != len(tt.wantRouted) {
+				t.Fatalf("service.UpdateTask() emitted %d events, want %d", len(emitted), len(tt.wantRouted))
+			}
+			for i, want := range tt.wantRouted {
+				if !sameProject(emitted[i].ProjectID, want) {
+					t.Errorf("event %d routed to %v, want %v", i, emitted[i].ProjectID, want)
+				}
+			}
+			// The previous project is not told what became of the task
+			if len(emitted) > 1 && strings.Contains(emitted[1].Payload, `"task":`) {
+				t.Errorf("event for the previous project carries the task: %s", emitted[1].Payload)
+			}
+		})
+	}
+}
+
 func Test_service_GetTaskByID(t *testing.T) {
 	taskID, _ := uuid.NewV4()
 	invalidID, _ := uuid.NewV4()
@@ -143,7 +343,7 @@ func Test_service_GetTaskByID(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.s.GetTaskByID(context.Background(), tt.id)
+			got, err := tt.s.GetTaskByID(context.Background(), tt.id, entities.TaskIncludes{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("service.GetTaskByID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -154,3 +354,218 @@ func Test_service_GetTaskByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_reads_projects(t *testing.T) {
+	projectID, _ := uuid.NewV4()
+	otherProjectID, _ := uuid.NewV4()
+	ownTaskID, _ := uuid.NewV4()
+	otherTaskID, _ := uuid.NewV4()
+
+	ctx := auth.WithCaller(context.Background(), auth.Caller{UserID: uuid.Must(uuid.NewV4()), ProjectID: &projectID})
+	visible := &entities.TaskFilter{
+		Clause: "(tasks.project_id IS NULL OR tasks.project_id = ?) AND (tasks.status = ?)",
+		Args:   []interface{}{projectID, entities.StatusPending},
+	}
+	userFilter := func() *entities.TaskFilter {
+		return &entities.TaskFilter{Clause: "tasks.status = ?", Args: []interface{}{entities.StatusPending}}
+	}
+
+	taskModel := taskMock.Task{}
+	taskModel.On("GetByID", mock.Anything, ownTaskID).Return(&entities.Task{ID: ownTaskID, ProjectID: &projectID}, nil)
+	taskModel.On("GetByID", mock.Anything, otherTaskID).Return(&entities.Task{ID: otherTaskID, ProjectID: &otherProjectID}, nil)
+	taskModel.On("GetAll", mock.Anything, entities.TaskListOptions{Filter: visible}).Return([]entities.Task{}, nil)
+	taskModel.On("Iterate", mock.Anything, entities.TaskListOptions{Filter: visible}, mock.Anything).Return(nil)
+	taskModel.On("Search", mock.Anything, entities.TaskSearchOptions{Query: "login", Filter: visible}).Return([]entities.TaskSearchHit{}, nil)
+
+	s := &service{model: models.Model{Task: &taskModel}, cfg: config.Service{ExportChunkSize: 10}}
+
+	if _, err := s.GetTaskByID(ctx, ownTaskID, entities.TaskIncludes{}); err != nil {
+		t.Errorf("GetTaskByID() of the caller's project error = %v", err)
+	}
+	if _, err := s.GetTaskByID(ctx, otherTaskID, entities.TaskIncludes{}); !errors.Is(err, entities.ErrTaskNotFound) {
+		t.Errorf("GetTaskByID() of another project error = %v, want ErrTaskNotFound", err)
+	}
+
+	// List, export and search only reach the tasks of the caller's project
+	// and those without one
+	if _, err := s.GetAllTasks(ctx, entities.TaskListOptions{Filter: userFilter()}); err != nil {
+		t.Errorf("GetAllTasks() error = %v", err)
+	}
+	if err := s.ExportTasks(ctx, entities.TaskListOptions{Filter: userFilter()}, func(*entities.TaskResponse) error { return nil }); err != nil {
+		t.Errorf("ExportTasks() error = %v", err)
+	}
+	if _, err := s.SearchTasks(ctx, entities.TaskSearchOptions{Query: "login", Filter: userFilter()}); err != nil {
+		t.Errorf("SearchTasks() error = %v", err)
+	}
+	taskModel.AssertExpectations(t)
+}
+
+func Test_service_SearchTasks(t *testing.T) {
+	taskID, _ := uuid.NewV4()
+	testTime := time.Now()
+
+	opts := entities.TaskSearchOptions{Query: "login", Page: 1, PageSize: 10}
+
+	hit := entities.TaskSearchHit{
+		Task: entities.Task{
+			ID:        taskID,
+			Title:     "Fix login bug",
+			Status:    entities.StatusPending,
+			CreatedAt: testTime,
+			UpdatedAt: testTime,
+		},
+		Rank:           0.5,
+		TitleHighlight: "Fix <mark>login</mark> bug",
+	}
+
+	expected := []*entities.TaskSearchResponse{
+		{
+			TaskResponse: entities.TaskResponse{
+				ID:        taskID,
+				Title:     "Fix login bug",
+				Status:    entities.StatusPending,
+				CreatedAt: testTime,
+				UpdatedAt: testTime,
+			},
+			Rank:       0.5,
+			Highlights: entities.TaskHighlights{Title: "Fix <mark>login</mark> bug"},
+		},
+	}
+
+	// Anonymous callers only search the tasks without a project
+	scoped := opts
+	scoped.Filter = &entities.TaskFilter{Clause: "tasks.project_id IS NULL"}
+
+	successMock := taskMock.Task{}
+	successMock.On("Search", mock.Anything, scoped).Return([]entities.TaskSearchHit{hit}, nil)
+
+	errorMock := taskMock.Task{}
+	errorMock.On("Search", mock.Anything, scoped).Return(nil, entities.ErrEmptyQuery)
+
+	tests := []struct {
+		name    string
+		s       *service
+		want    []*entities.TaskSearchResponse
+		wantErr bool
+	}{
+		{
+			name: "successful search",
+			s: &service{
+				model: models.Model{
+					Task: &successMock,
+				},
+			},
+			want:    expected,
+			wantErr: false,
+		},
+		{
+			name: "search error",
+			s: &service{
+				model: models.Model{
+					Task: &errorMock,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.SearchTasks(context.Background(), opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("service.SearchTasks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service.SearchTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetAllTasks_includeLabels(t *testing.T) {
+	firstID, _ := uuid.NewV4()
+	secondID, _ := uuid.NewV4()
+
+	opts := entities.TaskListOptions{Page: 1, PageSize: 10, Include: entities.TaskIncludes{Labels: true}}
+
+	tasks := []entities.Task{{ID: firstID, Title: "First"}, {ID: secondID, Title: "Second"}}
+
+	taskModel := taskMock.Task{}
+	scoped := opts
+	scoped.Filter = &entities.TaskFilter{Clause: "tasks.project_id IS NULL"}
+	taskModel.On("GetAll", mock.Anything, scoped).Return(tasks, nil)
+
+	labelModel := labelMock.Label{}
+	labelModel.On("GetByTaskIDs", mock.Anything, []uuid.UUID{firstID, secondID}).Return(map[uuid.UUID][]entities.Label{
+		firstID: {{Name: "backend"}, {Name: "bug"}},
+	}, nil).Once()
+
+	s := &service{model: models.Model{Task: &taskModel, Label: &labelModel}}
+
+	got, err := s.GetAllTasks(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("service.GetAllTasks() error = %v", err)
+	}
+	if !reflect.DeepEqual(got[0].Labels, []string{"backend", "bug"}) || !reflect.DeepEqual(got[1].Labels, []string{}) {
+		t.Errorf("service.GetAllTasks() labels = %v, %v", got[0].Labels, got[1].Labels)
+	}
+	if body, _ := json.Marshal(got[1]); !strings.Contains(string(body), `"labels":[]`) {
+		t.Errorf("task without labels rendered as %s, want an empty labels array", body)
+	}
+	labelModel.AssertExpectations(t)
+}
+
+func Test_service_ExportTasks(t *testing.T) {
+	opts := entities.TaskListOptions{Include: entities.TaskIncludes{Labels: true}}
+
+	cfg := config.Service{ExportChunkSize: 200}
+
+	// One full chunk plus a partial one
+	tasks := make([]entities.Task, cfg.ExportChunkSize+3)
+	for i := range tasks {
+		tasks[i].ID, _ = uuid.NewV4()
+	}
+
+	taskModel := taskMock.Task{}
+	scoped := opts
+	scoped.Filter = &entities.TaskFilter{Clause: "tasks.project_id IS NULL"}
+	taskModel.On("Iterate", mock.Anything, scoped, mock.Anything).Return(func(ctx context.Context, opts entities.TaskListOptions, fn func(*entities.Task) error) error {
+		for i := range tasks {
+			if err := fn(&tasks[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	labelModel := labelMock.Label{}
+	labelModel.On("GetByTaskIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID][]entities.Label{
+		tasks[0].ID: {{Name: "backend"}},
+	}, nil)
+
+	s := &service{model: models.Model{Task: &taskModel, Label: &labelModel}, cfg: cfg}
+
+	var got []*entities.TaskResponse
+	err := s.ExportTasks(context.Background(), opts, func(task *entities.TaskResponse) error {
+		got = append(got, task)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("service.ExportTasks() error = %v", err)
+	}
+
+	if len(got) != len(tasks) {
+		t.Fatalf("service.ExportTasks() exported %d tasks, want %d", len(got), len(tasks))
+	}
+	for i := range tasks {
+		if got[i].ID != tasks[i].ID {
+			t.Fatalf("service.ExportTasks() task %d out of order", i)
+		}
+	}
+	if !reflect.DeepEqual(got[0].Labels, []string{"backend"}) {
+		t.Errorf("service.ExportTasks() labels = %v", got[0].Labels)
+	}
+	labelModel.AssertNumberOfCalls(t, "GetByTaskIDs", 2)
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/filter"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// CreateView saves a new view owned by the caller
+func (s *service) CreateView(ctx context.Context, req *entities.ViewRequest) (*entities.ViewResponse, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthorized
+	}
+
+	if err := validateView(caller, req); err != nil {
+		return nil, err
+	}
+
+	view := &entities.View{UserID: caller.UserID}
+	applyViewRequest(view, req)
+
+	// Save to database
+	if err := s.model.View.Create(ctx, view); err != nil {
+		return nil, err
+	}
+
+	return newViewResponse(view), nil
+}
+
+// GetViewByID retrieves a view visible to the caller
+func (s *service) GetViewByID(ctx context.Context, id uuid.UUID) (*entities.ViewResponse, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthorized
+	}
+
+	view, err := s.visibleView(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newViewResponse(view), nil
+}
+
+// GetViews retrieves the caller's views and the views shared with their project
+func (s *service) GetViews(ctx context.Context) ([]*entities.ViewResponse, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthorized
+	}
+
+	views, err := s.model.View.GetVisible(ctx, caller.UserID, caller.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*entities.ViewResponse, len(views))
+	for i := range views {
+		response[i] = newViewResponse(&views[i])
+	}
+
+	return response, nil
+}
+
+// UpdateView updates a view owned by the caller
+func (s *service) UpdateView(ctx context.Context, id uuid.UUID, req *entities.ViewRequest) error {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return entities.ErrUnauthorized
+	}
+
+	view, err := s.visibleView(ctx, caller, id)
+	if err != nil {
+		return err
+	}
+	if view.UserID != caller.UserID {
+		return entities.ErrForbidden
+	}
+
+	if err := validateView(caller, req); err != nil {
+		return err
+	}
+	applyViewRequest(view, req)
+
+	// Save to database
+	return s.model.View.Update(ctx, view)
+}
+
+// DeleteView removes a view owned by the caller
+func (s *service) DeleteView(ctx context.Context, id uuid.UUID) error {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return entities.ErrUnauthorized
+	}
+
+	view, err := s.visibleView(ctx, caller, id)
+	if err != nil {
+		return err
+	}
+	if view.UserID != caller.UserID {
+		return entities.ErrForbidden
+	}
+
+	// Delete from database
+	return s.model.View.Delete(ctx, id)
+}
+
+// visibleView loads a view, hiding the views the caller cannot see behind
+// ErrViewNotFound so their existence is not disclosed
+func (s *service) visibleView(ctx context.Context, caller auth.Caller, id uuid.UUID) (*entities.View, error) {
+	view, err := s.model.View.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, entities.ErrViewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if view.UserID != caller.UserID && !(view.Shared && view.ProjectID != nil && caller.CanSeeProject(view.ProjectID)) {
+		return nil, entities.ErrViewNotFound
+	}
+
+	return view, nil
+}
+
+// validateView checks that the saved status is known, that the filter and
+// sort compile and that shared views belong to the caller's project
+func validateView(caller auth.Caller, req *entities.ViewRequest) error {
+	if req.Status != nil && !req.Status.Valid() {
+		return fmt.Errorf("%w: unknown status %q", entities.ErrInvalidView, *req.Status)
+	}
+	if _, err := entities.ParseTaskSort(req.Sort); err != nil {
+		return err
+	}
+	if _, err := filter.Parse(req.Filter); err != nil {
+		return err
+	}
+
+	if req.Shared {
+		if req.ProjectID == nil {
+			return fmt.Errorf("%w: shared views need a project_id", entities.ErrInvalidView)
+		}
+		if !caller.CanSeeProject(req.ProjectID) {
+			return entities.ErrForbidden
+		}
+	}
+
+	return nil
+}
+
+func applyViewRequest(view *entities.View, req *entities.ViewRequest) {
+	view.Name = req.Name
+	view.Filter = req.Filter
+	view.Status = req.Status
+	view.Sort = req.Sort
+	view.PageSize = req.PageSize
+	view.Shared = req.Shared
+	view.ProjectID = req.ProjectID
+}
+
+func newViewResponse(view *entities.View) *entities.ViewResponse {
+	return &entities.ViewResponse{
+		ID:        view.ID,
+		UserID:    view.UserID,
+		ProjectID: view.ProjectID,
+		Shared:    view.Shared,
+		Name:      view.Name,
+		Filter:    view.Filter,
+		Status:    view.Status,
+		Sort:      view.Sort,
+		PageSize:  view.PageSize,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+	}
+}
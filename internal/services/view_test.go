@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/models"
+	viewMock "task-management/internal/models/view/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_service_CreateView(t *testing.T) {
+	userID, _ := uuid.NewV4()
+	projectID, _ := uuid.NewV4()
+	otherProjectID, _ := uuid.NewV4()
+	bogusStatus := entities.TaskStatus("Bogus")
+
+	ctx := auth.WithCaller(context.Background(), auth.Caller{UserID: userID, ProjectID: &projectID})
+
+	successMock := viewMock.View{}
+	successMock.On("Create", mock.Anything, mock.MatchedBy(func(v *entities.View) bool {
+		return v.UserID == userID && v.Name == "My overdue tasks"
+	})).Return(nil)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *entities.ViewRequest
+		wantErr error
+	}{
+		{
+			name: "successful creation",
+			ctx:  ctx,
+			req: &entities.ViewRequest{
+				Name:      "My overdue tasks",
+				Filter:    "due<2026-11-01 AND NOT status:Completed",
+				Sort:      "due_date",
+				PageSize:  25,
+				Shared:    true,
+				ProjectID: &projectID,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "anonymous caller",
+			ctx:     context.Background(),
+			req:     &entities.ViewRequest{Name: "My overdue tasks"},
+			wantErr: entities.ErrUnauthorized,
+		},
+		{
+			name:    "invalid status",
+			ctx:     ctx,
+			req:     &entities.ViewRequest{Name: "My overdue tasks", Status: &bogusStatus},
+			wantErr: entities.ErrInvalidView,
+		},
+		{
+			name:    "invalid filter",
+			ctx:     ctx,
+			req:     &entities.ViewRequest{Name: "My overdue tasks", Filter: "owner:me"},
+			wantErr: entities.ErrInvalidFilter,
+		},
+		{
+			name:    "invalid sort",
+			ctx:     ctx,
+			req:     &entities.ViewRequest{Name: "My overdue tasks", Sort: "priority"},
+			wantErr: entities.ErrInvalidSort,
+		},
+		{
+			name:    "shared without project",
+			ctx:     ctx,
+			req:     &entities.ViewRequest{Name: "My overdue tasks", Shared: true},
+			wantErr: entities.ErrInvalidView,
+		},
+		{
+			name:    "shared with another project",
+			ctx:     ctx,
+			req:     &entities.ViewRequest{Name: "My overdue tasks", Shared: true, ProjectID: &otherProjectID},
+			wantErr: entities.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &service{model: models.Model{View: &successMock}}
+			_, err := s.CreateView(tt.ctx, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("service.CreateView() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_GetViewByID(t *testing.T) {
+	ownerID, _ := uuid.NewV4()
+	memberID, _ := uuid.NewV4()
+	projectID, _ := uuid.NewV4()
+	otherProjectID, _ := uuid.NewV4()
+	sharedID, _ := uuid.NewV4()
+	privateID, _ := uuid.NewV4()
+	missingID, _ := uuid.NewV4()
+
+	viewModel := viewMock.View{}
+	viewModel.On("GetByID", mock.Anything, sharedID).Return(&entities.View{
+		ID: sharedID, UserID: ownerID, Shared: true, ProjectID: &projectID,
+	}, nil)
+	viewModel.On("GetByID", mock.Anything, privateID).Return(&entities.View{
+		ID: privateID, UserID: ownerID, ProjectID: &projectID,
+	}, nil)
+	viewModel.On("GetByID", mock.Anything, missingID).Return(nil, gorm.ErrRecordNotFound)
+
+	tests := []struct {
+		name    string
+		caller  auth.Caller
+		id      uuid.UUID
+		wantErr error
+	}{
+		{
+			name:    "owner sees private view",
+			caller:  auth.Caller{UserID: ownerID},
+			id:      privateID,
+			wantErr: nil,
+		},
+		{
+			name:    "project member sees shared view",
+			caller:  auth.Caller{UserID: memberID, ProjectID: &projectID},
+			id:      sharedID,
+			wantErr: nil,
+		},
+		{
+			name:    "project member does not see private view",
+			caller:  auth.Caller{UserID: memberID, ProjectID: &projectID},
+			id:      privateID,
+			wantErr: entities.ErrViewNotFound,
+		},
+		{
+			name:    "other project does not see shared view",
+			caller:  auth.Caller{UserID: memberID, ProjectID: &otherProjectID},
+			id:      sharedID,
+			wantErr: entities.ErrViewNotFound,
+		},
+		{
+			name:    "missing view",
+			caller:  auth.Caller{UserID: ownerID},
+			id:      missingID,
+			wantErr: entities.ErrViewNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &service{model: models.Model{View: &viewModel}}
+			got, err := s.GetViewByID(auth.WithCaller(context.Background(), tt.caller), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("service.GetViewByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.ID != tt.id {
+				t.Errorf("service.GetViewByID() = %v, want id %v", got, tt.id)
+			}
+		})
+	}
+}
+
+func Test_service_DeleteView(t *testing.T) {
+	ownerID, _ := uuid.NewV4()
+	memberID, _ := uuid.NewV4()
+	projectID, _ := uuid.NewV4()
+	viewID, _ := uuid.NewV4()
+
+	viewModel := viewMock.View{}
+	viewModel.On("GetByID", mock.Anything, viewID).Return(&entities.View{
+		ID: viewID, UserID: ownerID, Shared: true, ProjectID: &projectID,
+	}, nil)
+	viewModel.On("Delete", mock.Anything, viewID).Return(nil)
+
+	tests := []struct {
+		name    string
+		caller  auth.Caller
+		wantErr error
+	}{
+		{
+			name:    "owner deletes view",
+			caller:  auth.Caller{UserID: ownerID},
+			wantErr: nil,
+		},
+		{
+			name:    "project member cannot delete shared view",
+			caller:  auth.Caller{UserID: memberID, ProjectID: &projectID},
+			wantErr: entities.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &service{model: models.Model{View: &viewModel}}
+			err := s.DeleteView(auth.WithCaller(context.Background(), tt.caller), viewID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("service.DeleteView() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
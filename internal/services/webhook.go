@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/webhook"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// CreateWebhook subscribes a URL owned by the caller to the events of the
+// tasks they can see. The signing secret is only returned here.
+func (s *service) CreateWebhook(ctx context.Context, req *entities.WebhookRequest) (*entities.WebhookResponse, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthorized
+	}
+	if err := webhook.CheckURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &entities.WebhookSubscription{UserID: caller.UserID, ProjectID: caller.ProjectID, Secret: secret}
+	applyWebhookRequest(subscription, req)
+
+	// Save to database
+	if err := s.model.Webhook.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	response := newWebhookResponse(subscription)
+	response.Secret = secret
+	return response, nil
+}
+
+// GetWebhooks retrieves the caller's subscriptions
+func (s *service) GetWebhooks(ctx context.Context) ([]*entities.WebhookResponse, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthorized
+	}
+
+	subscriptions, err := s.model.Webhook.GetByUser(ctx, caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*entities.WebhookResponse, len(subscriptions))
+	for i := range subscriptions {
+		response[i] = newWebhookResponse(&subscriptions[i])
+	}
+
+	return response, nil
+}
+
+// GetWebhookByID retrieves a subscription owned by the caller
+func (s *service) GetWebhookByID(ctx context.Context, id uuid.UUID) (*entities.WebhookResponse, error) {
+	subscription, err := s.ownWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWebhookResponse(subscription), nil
+}
+
+// UpdateWebhook updates a subscription owned by the caller
+func (s *service) UpdateWebhook(ctx context.Context, id uuid.UUID, req *entities.WebhookRequest) error {
+	subscription, err := s.ownWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := webhook.CheckURL(req.URL); err != nil {
+		return err
+	}
+	applyWebhookRequest(subscription, req)
+
+	// Save to database
+	return s.model.Webhook.Update(ctx, subscription)
+}
+
+// DeleteWebhook removes a subscription owned by the caller along with its
+// pending deliveries
+func (s *service) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.ownWebhook(ctx, id); err != nil {
+		return err
+	}
+
+	// Delete from database
+	return s.model.Webhook.Delete(ctx, id)
+}
+
+// GetWebhookDeliveries retrieves the delivery log of a subscription owned by
+// the caller, newest first
+func (s *service) GetWebhookDeliveries(ctx context.Context, id uuid.UUID, page, pageSize int) ([]*entities.WebhookDeliveryResponse, error) {
+	if _, err := s.ownWebhook(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.model.Webhook.GetDeliveries(ctx, id, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*entities.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		response[i] = &entities.WebhookDeliveryResponse{
+			ID:             d.ID,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Payload:        json.RawMessage(d.Payload),
+			Status:         d.Status,
+			Attempts:       d.Attempts,
+			ResponseStatus: d.ResponseStatus,
+			Error:          d.Error,
+			DeliveredAt:    d.DeliveredAt,
+			CreatedAt:      d.CreatedAt,
+		}
+		if d.Status == entities.DeliveryPending {
+			response[i].NextAttemptAt = &deliveries[i].NextAttemptAt
+		}
+	}
+
+	return response, nil
+}
+
+// ownWebhook loads a subscription, hiding the subscriptions of other users
+// behind ErrWebhookNotFound
+func (s *service) ownWebhook(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthorized
+	}
+
+	subscription, err := s.model.Webhook.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, entities.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subscription.UserID != caller.UserID {
+		return nil, entities.ErrWebhookNotFound
+	}
+
+	return subscription, nil
+}
+
+func applyWebhookRequest(subscription *entities.WebhookSubscription, req *entities.WebhookRequest) {
+	subscription.URL = req.URL
+	subscription.Events = strings.Join(req.Events, ",")
+	subscription.Active = req.Active == nil || *req.Active
+}
+
+func newWebhookResponse(subscription *entities.WebhookSubscription) *entities.WebhookResponse {
+	return &entities.WebhookResponse{
+		ID:        subscription.ID,
+		ProjectID: subscription.ProjectID,
+		URL:       subscription.URL,
+		Events:    entities.SplitEvents(subscription.Events),
+		Active:    subscription.Active,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/models"
+	webhookMock "task-management/internal/models/webhook/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_service_GetWebhookByID(t *testing.T) {
+	userID, _ := uuid.NewV4()
+	otherUserID, _ := uuid.NewV4()
+	ownID, _ := uuid.NewV4()
+	otherID, _ := uuid.NewV4()
+	missingID, _ := uuid.NewV4()
+
+	ctx := auth.WithCaller(context.Background(), auth.Caller{UserID: userID})
+
+	webhookModel := webhookMock.Webhook{}
+	webhookModel.On("GetByID", mock.Anything, ownID).Return(&entities.WebhookSubscription{ID: ownID, UserID: userID, Events: "task.created,task.deleted"}, nil)
+	webhookModel.On("GetByID", mock.Anything, otherID).Return(&entities.WebhookSubscription{ID: otherID, UserID: otherUserID}, nil)
+	webhookModel.On("GetByID", mock.Anything, missingID).Return(nil, gorm.ErrRecordNotFound)
+
+	s := &service{model: models.Model{Webhook: &webhookModel}}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		id      uuid.UUID
+		wantErr error
+	}{
+		{name: "own subscription", ctx: ctx, id: ownID},
+		{name: "other user's subscription", ctx: ctx, id: otherID, wantErr: entities.ErrWebhookNotFound},
+		{name: "missing subscription", ctx: ctx, id: missingID, wantErr: entities.ErrWebhookNotFound},
+		{name: "anonymous caller", ctx: context.Background(), id: ownID, wantErr: entities.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetWebhookByID(tt.ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("service.GetWebhookByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(got.Events) != 2 {
+				t.Errorf("service.GetWebhookByID() events = %v", got.Events)
+			}
+		})
+	}
+}
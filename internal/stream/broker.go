@@ -0,0 +1,196 @@
+// Package stream fans task events out to the clients connected to this
+// server instance. Events reach every instance through Postgres
+// notifications, so clients see the same stream whichever instance they are
+// connected to.
+package stream
+
+import (
+	"encoding/json"
+	"sync"
+
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// DefaultLogSize is the number of recent events kept to resume streams
+	DefaultLogSize = 1000
+
+	// subscriberBuffer is the number of events a subscriber may lag behind
+	// before it is dropped
+	subscriberBuffer = 256
+)
+
+// Event is a task event ready to be sent to clients. ID orders events in the
+// stream and is what clients resume from.
+type Event struct {
+	ID             int64
+	Type           entities.TaskEventType
+	ProjectID      *uuid.UUID
+	Status         *entities.TaskStatus
+	PreviousStatus *entities.TaskStatus
+	// Data is the JSON encoded TaskEvent
+	Data []byte
+}
+
+// NewEvent builds a stream event from an outbox event id and its payload
+func NewEvent(id int64, payload []byte) (Event, error) {
+	var task entities.TaskEvent
+	if err := json.Unmarshal(payload, &task); err != nil {
+		return Event{}, err
+	}
+
+	event := Event{
+		ID:             id,
+		Type:           task.Type,
+		ProjectID:      task.ProjectID,
+		PreviousStatus: task.PreviousStatus,
+		Data:           payload,
+	}
+	if task.Task != nil {
+		event.Status = &task.Task.Status
+	}
+
+	return event, nil
+}
+
+// Broker keeps a bounded log of recent events and passes new events to
+// subscribers
+type Broker struct {
+	mu sync.Mutex
+
+	// log is a ring buffer of the most recent events in arrival order,
+	// which is commit order
+	log   []Event
+	start int
+	size  int
+
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// NewBroker creates a broker remembering the last size events
+func NewBroker(size int) *Broker {
+	return &Broker{
+		log:         make([]Event, size),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events matching its filter. C is closed when
+// the subscription is dropped for lagging behind or when the broker resets;
+// clients should then reconnect and resume.
+type Subscription struct {
+	C <-chan Event
+
+	c      chan Event
+	filter func(Event) bool
+	broker *Broker
+}
+
+// Subscribe registers a subscriber. When lastEventID is given, the events
+// after it still in the log are returned as backlog. resumed is false when
+// lastEventID is no longer in the log, in which case the client missed
+// events and should reload its state.
+func (b *Broker) Subscribe(lastEventID *int64, filter func(Event) bool) (sub *Subscription, backlog []Event, resumed bool) {
+	c := make(chan Event, subscriberBuffer)
+	sub = &Subscription{C: c, c: c, filter: filter, broker: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(c)
+		return sub, nil, true
+	}
+
+	b.subscribers[sub] = struct{}{}
+	if lastEventID == nil {
+		return sub, nil, true
+	}
+
+	found := false
+	for i := 0; i < b.size; i++ {
+		event := b.log[(b.start+i)%len(b.log)]
+		if found && filter(event) {
+			backlog = append(backlog, event)
+		}
+		if event.ID == *lastEventID {
+			found = true
+		}
+	}
+
+	return sub, backlog, found
+}
+
+// Close unregisters the subscription
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.drop(s)
+}
+
+// Publish appends events to the log and passes them to the subscribers
+func (b *Broker) Publish(events ...Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, event := range events {
+		if b.size < len(b.log) {
+			b.log[(b.start+b.size)%len(b.log)] = event
+			b.size++
+		} else {
+			b.log[b.start] = event
+			b.start = (b.start + 1) % len(b.log)
+		}
+
+		for sub := range b.subscribers {
+			if !sub.filter(event) {
+				continue
+			}
+			select {
+			case sub.c <- event:
+			default:
+				// The client cannot keep up; it resumes from the log once it
+				// reconnects
+				b.drop(sub)
+			}
+		}
+	}
+}
+
+// Reset forgets the log and drops every subscriber. It is used after events
+// may have been missed, so resuming clients are told to reload.
+func (b *Broker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.start, b.size = 0, 0
+	for sub := range b.subscribers {
+		b.drop(sub)
+	}
+}
+
+// Close drops every subscriber and closes later subscriptions right away,
+// ending the streams of a server shutting down. Consumers which subscribe
+// again whenever their channel closes must be stopped first.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subscribers {
+		b.drop(sub)
+	}
+}
+
+// drop removes a subscriber and closes its channel. b.mu must be held.
+func (b *Broker) drop(sub *Subscription) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.c)
+}
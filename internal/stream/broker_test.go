@@ -0,0 +1,215 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	outboxMock "task-management/internal/models/outbox/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func all(Event) bool { return true }
+
+func ids(events []Event) []int64 {
+	out := make([]int64, len(events))
+	for i, e := range events {
+		out[i] = e.ID
+	}
+	return out
+}
+
+func equal(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBroker_resume(t *testing.T) {
+	b := NewBroker(3)
+	// Commit order differs from id order, the log keeps arrival order
+	b.Publish(Event{ID: 1}, Event{ID: 3}, Event{ID: 2}, Event{ID: 4})
+
+	tests := []struct {
+		name        string
+		last        int64
+		want        []int64
+		wantResumed bool
+	}{
+		{name: "resume in the middle", last: 3, want: []int64{2, 4}, wantResumed: true},
+		{name: "up to date", last: 4, want: nil, wantResumed: true},
+		{name: "evicted from the log", last: 1, want: nil, wantResumed: false},
+		{name: "unknown", last: 99, want: nil, wantResumed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, backlog, resumed := b.Subscribe(&tt.last, all)
+			defer sub.Close()
+
+			if resumed != tt.wantResumed {
+				t.Errorf("Subscribe() resumed = %v, want %v", resumed, tt.wantResumed)
+			}
+			if !equal(ids(backlog), tt.want) {
+				t.Errorf("Subscribe() backlog = %v, want %v", ids(backlog), tt.want)
+			}
+		})
+	}
+}
+
+func TestBroker_fanOut(t *testing.T) {
+	b := NewBroker(DefaultLogSize)
+
+	even, _, _ := b.Subscribe(nil, func(e Event) bool { return e.ID%2 == 0 })
+	slow, _, _ := b.Subscribe(nil, all)
+	defer even.Close()
+
+	for i := int64(1); i <= subscriberBuffer+1; i++ {
+		b.Publish(Event{ID: i})
+	}
+
+	if got := <-even.C; got.ID != 2 {
+		t.Errorf("filtered subscriber got event %d first, want 2", got.ID)
+	}
+
+	// The slow subscriber overflowed its buffer and was dropped
+	n := 0
+	for range slow.C {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("slow subscriber got %d events before being dropped, want %d", n, subscriberBuffer)
+	}
+	slow.Close()
+
+	// Reset drops every subscriber, which ends their stream once drained
+	b.Reset()
+	for range even.C {
+	}
+
+	last := int64(2)
+	sub, _, resumed := b.Subscribe(&last, all)
+	defer sub.Close()
+	if resumed {
+		t.Error("Subscribe() resumed after Reset")
+	}
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := NewBroker(DefaultLogSize)
+
+	before, _, _ := b.Subscribe(nil, all)
+	b.Close()
+	if _, ok := <-before.C; ok {
+		t.Error("subscription made before Close is still open")
+	}
+
+	after, _, _ := b.Subscribe(nil, all)
+	defer after.Close()
+	b.Publish(Event{ID: 1})
+	if _, ok := <-after.C; ok {
+		t.Error("subscription made after Close is open")
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	project, _ := uuid.NewV4()
+	other, _ := uuid.NewV4()
+	pending := entities.StatusPending
+	completed := entities.StatusCompleted
+
+	tests := []struct {
+		name   string
+		filter Filter
+		event  Event
+		want   bool
+	}{
+		{
+			name:   "task without project",
+			filter: Filter{},
+			event:  Event{Status: &pending},
+			want:   true,
+		},
+		{
+			name:   "project the caller cannot see",
+			filter: Filter{Caller: auth.Caller{ProjectID: &other}},
+			event:  Event{ProjectID: &project},
+			want:   false,
+		},
+		{
+			name:   "caller's project",
+			filter: Filter{Caller: auth.Caller{ProjectID: &project}, ProjectID: &project},
+			event:  Event{ProjectID: &project},
+			want:   true,
+		},
+		{
+			name:   "other project than requested",
+			filter: Filter{ProjectID: &project},
+			event:  Event{},
+			want:   false,
+		},
+		{
+			name:   "status outside the filter",
+			filter: Filter{Statuses: []entities.TaskStatus{pending}},
+			event:  Event{Status: &completed},
+			want:   false,
+		},
+		{
+			name:   "task leaving a filtered status",
+			filter: Filter{Statuses: []entities.TaskStatus{pending}},
+			event:  Event{Type: entities.EventTaskStatusChanged, Status: &completed, PreviousStatus: &pending},
+			want:   true,
+		},
+		{
+			name:   "deletion",
+			filter: Filter{Statuses: []entities.TaskStatus{pending}},
+			event:  Event{Type: entities.EventTaskDeleted},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.event); got != tt.want {
+				t.Errorf("Filter.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_eventFromNotification(t *testing.T) {
+	payload := `{"type":"task.status_changed","task":{"status":"Completed"},"previous_status":"Pending"}`
+
+	model := outboxMock.Outbox{}
+	model.On("GetByIDs", mock.Anything, []int64{8}).Return([]entities.OutboxEvent{{ID: 8, Payload: payload}}, nil)
+
+	inline, err := eventFromNotification(context.Background(), &model, "7 "+payload)
+	if err != nil {
+		t.Fatalf("eventFromNotification() error = %v", err)
+	}
+	if inline.ID != 7 || inline.Type != entities.EventTaskStatusChanged || *inline.Status != entities.StatusCompleted || *inline.PreviousStatus != entities.StatusPending {
+		t.Errorf("eventFromNotification() = %+v", inline)
+	}
+
+	// Large payloads are only announced by id
+	loaded, err := eventFromNotification(context.Background(), &model, "8")
+	if err != nil {
+		t.Fatalf("eventFromNotification() error = %v", err)
+	}
+	if loaded.ID != 8 || string(loaded.Data) != payload {
+		t.Errorf("eventFromNotification() = %+v", loaded)
+	}
+
+	if _, err := eventFromNotification(context.Background(), &model, "nope"); err == nil {
+		t.Error("eventFromNotification() accepted an invalid notification")
+	}
+}
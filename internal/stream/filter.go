@@ -0,0 +1,40 @@
+package stream
+
+import (
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+
+	"github.com/gofrs/uuid"
+)
+
+// Filter selects the events sent to a client
+type Filter struct {
+	// Caller limits events to the projects the caller can see
+	Caller auth.Caller
+	// ProjectID limits events to one project
+	ProjectID *uuid.UUID
+	// Statuses limits events to tasks in, or leaving, one of the statuses.
+	// Deletions always match so clients can drop the task.
+	Statuses []entities.TaskStatus
+}
+
+// Match reports whether the event passes the filter
+func (f Filter) Match(event Event) bool {
+	if !f.Caller.CanSeeProject(event.ProjectID) {
+		return false
+	}
+
+	if f.ProjectID != nil && (event.ProjectID == nil || *event.ProjectID != *f.ProjectID) {
+		return false
+	}
+
+	if len(f.Statuses) == 0 || event.Status == nil {
+		return true
+	}
+	for _, status := range f.Statuses {
+		if *event.Status == status || (event.PreviousStatus != nil && *event.PreviousStatus == status) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	outboxModel "task-management/internal/models/outbox"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// reconnectDelay is the wait before listening again after a failure
+const reconnectDelay = 5 * time.Second
+
+// Listen publishes the outbox events announced by Postgres to the broker
+// until ctx is cancelled. Notifications are lost while the connection is
+// down, so the broker is reset after every reconnection.
+func Listen(ctx context.Context, dsn string, model outboxModel.Outbox, broker *Broker) {
+	connected := false
+	for {
+		err := listen(ctx, dsn, model, broker, func() {
+			if connected {
+				broker.Reset()
+			}
+			connected = true
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.ErrorContext(ctx, "event listener: connection lost", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// listen runs a single LISTEN session, calling ready once it is listening
+func listen(ctx context.Context, dsn string, model outboxModel.Outbox, broker *Broker, ready func()) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{outboxModel.NotifyChannel}.Sanitize()); err != nil {
+		return err
+	}
+	ready()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		event, err := eventFromNotification(ctx, model, notification.Payload)
+		if err != nil {
+			slog.WarnContext(ctx, "event listener: skipping notification", "error", err)
+			continue
+		}
+		broker.Publish(event)
+	}
+}
+
+// eventFromNotification decodes a notification, loading the event from the
+// outbox when its payload did not fit in the notification
+func eventFromNotification(ctx context.Context, model outboxModel.Outbox, payload string) (Event, error) {
+	rawID, data, _ := strings.Cut(payload, " ")
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return Event{}, fmt.Errorf("invalid notification %q", payload)
+	}
+
+	if data == "" {
+		rows, err := model.GetByIDs(ctx, []int64{id})
+		if err != nil {
+			return Event{}, err
+		}
+		if len(rows) == 0 {
+			return Event{}, fmt.Errorf("outbox event %d not found", id)
+		}
+		data = rows[0].Payload
+	}
+
+	return NewEvent(id, []byte(data))
+}
@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+// querySpan is the span of a running query and the context it replaced
+type querySpan struct {
+	span   trace.Span
+	parent context.Context
+}
+
+// GormPlugin records a client span for every query run through gorm, as a
+// child of the span in the statement's context. Register it with
+// db.Use(tracing.GormPlugin{}).
+type GormPlugin struct{}
+
+// Name implements gorm.Plugin
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize implements gorm.Plugin by wrapping every callback chain
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("tracing:before_create", start("create")),
+		cb.Create().After("*").Register("tracing:after_create", end),
+		cb.Query().Before("*").Register("tracing:before_query", start("query")),
+		cb.Query().After("*").Register("tracing:after_query", end),
+		cb.Update().Before("*").Register("tracing:before_update", start("update")),
+		cb.Update().After("*").Register("tracing:after_update", end),
+		cb.Delete().Before("*").Register("tracing:before_delete", start("delete")),
+		cb.Delete().After("*").Register("tracing:after_delete", end),
+		cb.Row().Before("*").Register("tracing:before_row", start("row")),
+		cb.Row().After("*").Register("tracing:after_row", end),
+		cb.Raw().Before("*").Register("tracing:before_raw", start("raw")),
+		cb.Raw().After("*").Register("tracing:after_raw", end),
+	)
+}
+
+// start returns the callback opening the span of an operation
+func start(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		parent := tx.Statement.Context
+		ctx, span := tracer().Start(parent, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemNamePostgreSQL,
+				semconv.DBOperationName(operation),
+			),
+		)
+		tx.Statement.Context = ctx
+		tx.InstanceSet(spanKey, querySpan{span: span, parent: parent})
+	}
+}
+
+// end closes the span opened by start, once the statement is built and run,
+// and gives the statement its context back
+func end(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	qs, ok := value.(querySpan)
+	if !ok {
+		return
+	}
+	span := qs.span
+	defer span.End()
+	tx.Statement.Context = qs.parent
+
+	if tx.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(tx.Statement.Table))
+	}
+	if sql := tx.Statement.SQL.String(); sql != "" {
+		span.SetAttributes(semconv.DBQueryText(sql))
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", tx.Statement.RowsAffected))
+
+	if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(tx.Error)
+		span.SetStatus(codes.Error, tx.Error.Error())
+	}
+}
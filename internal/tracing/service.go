@@ -0,0 +1,293 @@
+package tracing
+
+import (
+	"context"
+
+	"task-management/internal/entities"
+	"task-management/internal/services"
+
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attributes describing the tasks a call works on
+const (
+	TaskIDKey     = attribute.Key("task.id")
+	TaskStatusKey = attribute.Key("task.status")
+	TaskCountKey  = attribute.Key("task.count")
+)
+
+// service records a span around every call to the wrapped service
+type service struct {
+	next services.Service
+}
+
+// Service wraps s so each of its calls is recorded as a span, carrying the
+// IDs and statuses of the tasks involved
+func Service(s services.Service) services.Service {
+	return &service{next: s}
+}
+
+// startCall opens the span of a service method
+func startCall(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "services."+method, trace.WithAttributes(attrs...))
+}
+
+// endCall records err, if any, and ends span
+func endCall(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func taskID(id uuid.UUID) attribute.KeyValue {
+	return TaskIDKey.String(id.String())
+}
+
+func taskStatus(status entities.TaskStatus) attribute.KeyValue {
+	return TaskStatusKey.String(string(status))
+}
+
+// statusFilter returns the attribute of an optional status filter
+func statusFilter(status *entities.TaskStatus) []attribute.KeyValue {
+	if status == nil {
+		return nil
+	}
+	return []attribute.KeyValue{taskStatus(*status)}
+}
+
+func (s *service) CreateTask(ctx context.Context, req *entities.TaskRequest) (task *entities.TaskResponse, err error) {
+	ctx, span := startCall(ctx, "CreateTask", taskStatus(req.Status))
+	defer func() { endCall(span, err) }()
+
+	task, err = s.next.CreateTask(ctx, req)
+	if task != nil {
+		span.SetAttributes(taskID(task.ID))
+	}
+	return task, err
+}
+
+func (s *service) GetTaskByID(ctx context.Context, id uuid.UUID, include entities.TaskIncludes) (task *entities.TaskResponse, err error) {
+	ctx, span := startCall(ctx, "GetTaskByID", taskID(id))
+	defer func() { endCall(span, err) }()
+
+	task, err = s.next.GetTaskByID(ctx, id, include)
+	if task != nil {
+		span.SetAttributes(taskStatus(task.Status))
+	}
+	return task, err
+}
+
+func (s *service) GetAllTasks(ctx context.Context, opts entities.TaskListOptions) (tasks []*entities.TaskResponse, err error) {
+	ctx, span := startCall(ctx, "GetAllTasks", statusFilter(opts.Status)...)
+	defer func() { endCall(span, err) }()
+
+	tasks, err = s.next.GetAllTasks(ctx, opts)
+	span.SetAttributes(TaskCountKey.Int(len(tasks)))
+	return tasks, err
+}
+
+func (s *service) ExportTasks(ctx context.Context, opts entities.TaskListOptions, fn func(task *entities.TaskResponse) error) (err error) {
+	ctx, span := startCall(ctx, "ExportTasks", statusFilter(opts.Status)...)
+	defer func() { endCall(span, err) }()
+
+	count := 0
+	err = s.next.ExportTasks(ctx, opts, func(task *entities.TaskResponse) error {
+		count++
+		return fn(task)
+	})
+	span.SetAttributes(TaskCountKey.Int(count))
+	return err
+}
+
+func (s *service) SearchTasks(ctx context.Context, opts entities.TaskSearchOptions) (hits []*entities.TaskSearchResponse, err error) {
+	ctx, span := startCall(ctx, "SearchTasks", statusFilter(opts.Status)...)
+	defer func() { endCall(span, err) }()
+
+	hits, err = s.next.SearchTasks(ctx, opts)
+	span.SetAttributes(TaskCountKey.Int(len(hits)))
+	return hits, err
+}
+
+func (s *service) GetTaskLabels(ctx context.Context, taskIDs []uuid.UUID) (labels map[uuid.UUID][]string, err error) {
+	ctx, span := startCall(ctx, "GetTaskLabels", TaskCountKey.Int(len(taskIDs)))
+	defer func() { endCall(span, err) }()
+
+	return s.next.GetTaskLabels(ctx, taskIDs)
+}
+
+func (s *service) UpdateTask(ctx context.Context, id uuid.UUID, req *entities.TaskRequest) (err error) {
+	ctx, span := startCall(ctx, "UpdateTask", taskID(id), taskStatus(req.Status))
+	defer func() { endCall(span, err) }()
+
+	return s.next.UpdateTask(ctx, id, req)
+}
+
+func (s *service) DeleteTask(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startCall(ctx, "DeleteTask", taskID(id))
+	defer func() { endCall(span, err) }()
+
+	return s.next.DeleteTask(ctx, id)
+}
+
+func (s *service) BulkTasks(ctx context.Context, req *entities.BulkRequest) (resp *entities.BulkResponse, err error) {
+	ctx, span := startCall(ctx, "BulkTasks",
+		TaskCountKey.Int(len(req.Items)),
+		attribute.Bool("bulk.atomic", req.IsAtomic()),
+	)
+	defer func() { endCall(span, err) }()
+
+	resp, err = s.next.BulkTasks(ctx, req)
+	if resp != nil {
+		span.SetAttributes(
+			attribute.Int("bulk.succeeded", resp.Succeeded),
+			attribute.Int("bulk.failed", resp.Failed),
+		)
+	}
+	return resp, err
+}
+
+func (s *service) ImportTasks(ctx context.Context, rows []entities.ImportRow, dryRun bool) (result *entities.ImportResult, err error) {
+	ctx, span := startCall(ctx, "ImportTasks",
+		TaskCountKey.Int(len(rows)),
+		attribute.Bool("import.dry_run", dryRun),
+	)
+	defer func() { endCall(span, err) }()
+
+	result, err = s.next.ImportTasks(ctx, rows, dryRun)
+	if result != nil {
+		span.SetAttributes(
+			attribute.Int("import.imported", result.Imported),
+			attribute.Int("import.errors", len(result.Errors)),
+		)
+	}
+	return result, err
+}
+
+func (s *service) CreateView(ctx context.Context, req *entities.ViewRequest) (view *entities.ViewResponse, err error) {
+	ctx, span := startCall(ctx, "CreateView")
+	defer func() { endCall(span, err) }()
+
+	view, err = s.next.CreateView(ctx, req)
+	if view != nil {
+		span.SetAttributes(attribute.String("view.id", view.ID.String()))
+	}
+	return view, err
+}
+
+func (s *service) GetViewByID(ctx context.Context, id uuid.UUID) (view *entities.ViewResponse, err error) {
+	ctx, span := startCall(ctx, "GetViewByID", attribute.String("view.id", id.String()))
+	defer func() { endCall(span, err) }()
+
+	return s.next.GetViewByID(ctx, id)
+}
+
+func (s *service) GetViews(ctx context.Context) (views []*entities.ViewResponse, err error) {
+	ctx, span := startCall(ctx, "GetViews")
+	defer func() { endCall(span, err) }()
+
+	return s.next.GetViews(ctx)
+}
+
+func (s *service) UpdateView(ctx context.Context, id uuid.UUID, req *entities.ViewRequest) (err error) {
+	ctx, span := startCall(ctx, "UpdateView", attribute.String("view.id", id.String()))
+	defer func() { endCall(span, err) }()
+
+	return s.next.UpdateView(ctx, id, req)
+}
+
+func (s *service) DeleteView(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startCall(ctx, "DeleteView", attribute.String("view.id", id.String()))
+	defer func() { endCall(span, err) }()
+
+	return s.next.DeleteView(ctx, id)
+}
+
+func (s *service) CreateCalendarToken(ctx context.Context, req *entities.CalendarTokenRequest) (token *entities.CalendarTokenResponse, err error) {
+	ctx, span := startCall(ctx, "CreateCalendarToken")
+	defer func() { endCall(span, err) }()
+
+	return s.next.CreateCalendarToken(ctx, req)
+}
+
+func (s *service) GetCalendarTokens(ctx context.Context) (tokens []*entities.CalendarTokenResponse, err error) {
+	ctx, span := startCall(ctx, "GetCalendarTokens")
+	defer func() { endCall(span, err) }()
+
+	return s.next.GetCalendarTokens(ctx)
+}
+
+func (s *service) DeleteCalendarToken(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startCall(ctx, "DeleteCalendarToken", attribute.String("calendar_token.id", id.String()))
+	defer func() { endCall(span, err) }()
+
+	return s.next.DeleteCalendarToken(ctx, id)
+}
+
+// CalendarFeed leaves the token out of the span, it grants access to the feed
+func (s *service) CalendarFeed(ctx context.Context, opts entities.CalendarFeedOptions, fn func(task *entities.TaskResponse) error) (err error) {
+	statuses := make([]string, len(opts.Statuses))
+	for i, status := range opts.Statuses {
+		statuses[i] = string(status)
+	}
+	ctx, span := startCall(ctx, "CalendarFeed", TaskStatusKey.StringSlice(statuses))
+	defer func() { endCall(span, err) }()
+
+	count := 0
+	err = s.next.CalendarFeed(ctx, opts, func(task *entities.TaskResponse) error {
+		count++
+		return fn(task)
+	})
+	span.SetAttributes(TaskCountKey.Int(count))
+	return err
+}
+
+func (s *service) CreateWebhook(ctx context.Context, req *entities.WebhookRequest) (webhook *entities.WebhookResponse, err error) {
+	ctx, span := startCall(ctx, "CreateWebhook")
+	defer func() { endCall(span, err) }()
+
+	webhook, err = s.next.CreateWebhook(ctx, req)
+	if webhook != nil {
+		span.SetAttributes(attribute.String("webhook.id", webhook.ID.String()))
+	}
+	return webhook, err
+}
+
+func (s *service) GetWebhooks(ctx context.Context) (webhooks []*entities.WebhookResponse, err error) {
+	ctx, span := startCall(ctx, "GetWebhooks")
+	defer func() { endCall(span, err) }()
+
+	return s.next.GetWebhooks(ctx)
+}
+
+func (s *service) GetWebhookByID(ctx context.Context, id uuid.UUID) (webhook *entities.WebhookResponse, err error) {
+	ctx, span := startCall(ctx, "GetWebhookByID", attribute.String("webhook.id", id.String()))
+	defer func() { endCall(span, err) }()
+
+	return s.next.GetWebhookByID(ctx, id)
+}
+
+func (s *service) UpdateWebhook(ctx context.Context, id uuid.UUID, req *entities.WebhookRequest) (err error) {
+	ctx, span := startCall(ctx, "UpdateWebhook", attribute.String("webhook.id", id.String()))
+	defer func() { endCall(span, err) }()
+
+	return s.next.UpdateWebhook(ctx, id, req)
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startCall(ctx, "DeleteWebhook", attribute.String("webhook.id", id.String()))
+	defer func() { endCall(span, err) }()
+
+	return s.next.DeleteWebhook(ctx, id)
+}
+
+func (s *service) GetWebhookDeliveries(ctx context.Context, id uuid.UUID, page, pageSize int) (deliveries []*entities.WebhookDeliveryResponse, err error) {
+	ctx, span := startCall(ctx, "GetWebhookDeliveries", attribute.String("webhook.id", id.String()))
+	defer func() { endCall(span, err) }()
+
+	return s.next.GetWebhookDeliveries(ctx, id, page, pageSize)
+}
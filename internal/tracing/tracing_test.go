@@ -0,0 +1,176 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"task-management/internal/entities"
+	"task-management/internal/services/mocks"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// record installs a provider recording every span for the duration of the
+// test
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return recorder
+}
+
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := record(t)
+
+	router := mux.NewRouter()
+	router.Use(Middleware)
+	router.HandleFunc("/api/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if !trace.SpanFromContext(r.Context()).SpanContext().IsValid() {
+			t.Error("handler context carries no span")
+		}
+		http.Error(w, "database unavailable", http.StatusInternalServerError)
+	})
+
+	r := httptest.NewRequest("GET", "/api/tasks/1", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("spans = %d, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /api/tasks/{id}" {
+		t.Errorf("name = %q, want the route template", span.Name())
+	}
+	if got := span.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %s, want the caller's", got)
+	}
+	if got := span.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span ID = %s, want the caller's", got)
+	}
+	if got := attr(span, "http.response.status_code").AsInt64(); got != 500 {
+		t.Errorf("status code = %d, want 500", got)
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", span.Status().Code)
+	}
+}
+
+func TestService(t *testing.T) {
+	recorder := record(t)
+
+	id := uuid.Must(uuid.NewV4())
+	next := &mocks.Service{}
+	next.On("GetTaskByID", mock.Anything, id, entities.TaskIncludes{}).
+		Return(&entities.TaskResponse{ID: id, Status: entities.StatusInProgress}, nil)
+	next.On("UpdateTask", mock.Anything, id, mock.Anything).Return(errors.New("task not found"))
+
+	s := Service(next)
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	s.GetTaskByID(ctx, id, entities.TaskIncludes{})
+	s.UpdateTask(ctx, id, &entities.TaskRequest{Status: entities.StatusCompleted})
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("spans = %d, want 3", len(spans))
+	}
+
+	get, update := spans[0], spans[1]
+	if get.Name() != "services.GetTaskByID" || get.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("span %q is not a child of the request", get.Name())
+	}
+	if got := attr(get, TaskIDKey).AsString(); got != id.String() {
+		t.Errorf("task.id = %q, want %s", got, id)
+	}
+	if got := attr(get, TaskStatusKey).AsString(); got != string(entities.StatusInProgress) {
+		t.Errorf("task.status = %q, want the loaded task's", got)
+	}
+	if got := attr(update, TaskStatusKey).AsString(); got != string(entities.StatusCompleted) {
+		t.Errorf("task.status = %q, want the requested one", got)
+	}
+	if update.Status().Code != codes.Error || update.Status().Description != "task not found" {
+		t.Errorf("span status = %+v, want the error", update.Status())
+	}
+}
+
+func TestGormPlugin(t *testing.T) {
+	recorder := record(t)
+
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+
+	sqlMock.ExpectQuery(`SELECT \* FROM "tasks"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.Must(uuid.NewV4())))
+	sqlMock.ExpectQuery(`SELECT \* FROM "tasks"`).WillReturnError(errors.New("connection reset"))
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	var tasks []entities.Task
+	tx := db.WithContext(ctx)
+	tx.Find(&tasks)
+	tx.Find(&tasks)
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("spans = %d, want 3", len(spans))
+	}
+	for _, span := range spans[:2] {
+		if span.Name() != "db.query" || span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %q is not a query child of the request", span.Name())
+		}
+		if got := attr(span, "db.collection.name").AsString(); got != "tasks" {
+			t.Errorf("db.collection.name = %q, want tasks", got)
+		}
+	}
+	if got := attr(spans[0], "db.rows_affected").AsInt64(); got != 1 {
+		t.Errorf("db.rows_affected = %d, want 1", got)
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("failed query status = %v, want error", spans[1].Status().Code)
+	}
+}
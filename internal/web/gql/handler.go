@@ -0,0 +1,95 @@
+package gql
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"task-management/internal/entities"
+	"task-management/internal/services"
+
+	"github.com/go-playground/validator"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// request is the body of a GraphQL request
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type handler struct {
+	schema  graphql.Schema
+	service services.Service
+}
+
+// NewHandler returns the handler of POST /graphql
+func NewHandler(service services.Service, validate *validator.Validate) (http.Handler, error) {
+	schema, err := NewSchema(service, validate)
+	if err != nil {
+		return nil, err
+	}
+	return &handler{schema: schema, service: service}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Query == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Errors are reported in the result, as GraphQL clients expect
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := checkLimits(req.Query); err != nil {
+		json.NewEncoder(w).Encode(&graphql.Result{Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormatError(&gqlerrors.Error{Message: err.Error(), OriginalError: err}),
+		}})
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        withLoader(r.Context(), h.service),
+	})
+	json.NewEncoder(w).Encode(result)
+}
+
+// resolverError carries a machine readable code in the error extensions,
+// following the HTTP statuses of the REST API
+type resolverError struct {
+	err  error
+	code string
+}
+
+func (e *resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func badRequest(message string) error {
+	return &resolverError{err: errors.New(message), code: "BAD_REQUEST"}
+}
+
+// errorCodes are the codes reported for each kind of service error
+var errorCodes = map[entities.ErrorKind]string{
+	entities.KindInternal:        "INTERNAL",
+	entities.KindInvalid:         "BAD_REQUEST",
+	entities.KindUnauthenticated: "UNAUTHENTICATED",
+	entities.KindForbidden:       "FORBIDDEN",
+	entities.KindNotFound:        "NOT_FOUND",
+}
+
+// codedError maps service errors to the code reported to the client
+func codedError(err error) error {
+	return &resolverError{err: err, code: errorCodes[entities.KindOf(err)]}
+}
@@ -0,0 +1,180 @@
+package gql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"task-management/internal/entities"
+	"task-management/internal/services/mocks"
+
+	"github.com/go-playground/validator"
+	"github.com/gofrs/uuid"
+	"github.com/graphql-go/graphql/testutil"
+	"github.com/stretchr/testify/mock"
+)
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func do(t *testing.T, service *mocks.Service, query string, variables map[string]interface{}) response {
+	h, err := NewHandler(service, validator.New())
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	body, _ := json.Marshal(request{Query: query, Variables: variables})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+
+	var resp response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response %s: %v", rec.Body, err)
+	}
+	return resp
+}
+
+func TestTasks_batchesLabels(t *testing.T) {
+	ids := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+	tasks := make([]*entities.TaskResponse, len(ids))
+	for i, id := range ids {
+		tasks[i] = &entities.TaskResponse{ID: id, Title: "Task", Status: entities.StatusPending}
+	}
+
+	status := entities.StatusPending
+	service := &mocks.Service{}
+	service.On("GetAllTasks", mock.Anything, mock.MatchedBy(func(opts entities.TaskListOptions) bool {
+		return opts.Page == 2 && opts.PageSize == 3 && *opts.Status == status && opts.Filter != nil && !opts.Include.Labels
+	})).Return(tasks, nil)
+	service.On("GetTaskLabels", mock.Anything, ids).Return(map[uuid.UUID][]string{
+		ids[0]: {"backend"},
+		ids[1]: {},
+		ids[2]: {"bug", "ui"},
+	}, nil).Once()
+
+	resp := do(t, service, `query($filter: String) {
+		tasks(page: 2, pageSize: 3, status: Pending, filter: $filter) { id status labels }
+	}`, map[string]interface{}{"filter": "label:backend"})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors = %v", resp.Errors)
+	}
+
+	var got []struct {
+		ID     string
+		Status string
+		Labels []string
+	}
+	json.Unmarshal(resp.Data["tasks"], &got)
+	if len(got) != 3 || got[0].Status != "Pending" || len(got[0].Labels) != 1 || len(got[1].Labels) != 0 || len(got[2].Labels) != 2 {
+		t.Errorf("tasks = %+v", got)
+	}
+	service.AssertNumberOfCalls(t, "GetTaskLabels", 1)
+}
+
+func TestTasks_pageSize(t *testing.T) {
+	service := &mocks.Service{}
+	service.On("GetAllTasks", mock.Anything, mock.MatchedBy(func(opts entities.TaskListOptions) bool {
+		return opts.PageSize == entities.MaxPageSize
+	})).Return([]*entities.TaskResponse{}, nil)
+
+	resp := do(t, service, `{ tasks(pageSize: 1000000) { id } }`, nil)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors = %v, want the page size clamped", resp.Errors)
+	}
+	service.AssertExpectations(t)
+}
+
+func TestTask_notFound(t *testing.T) {
+	service := &mocks.Service{}
+	service.On("GetTaskByID", mock.Anything, mock.Anything, entities.TaskIncludes{}).Return(nil, entities.ErrTaskNotFound)
+
+	resp := do(t, service, `{ task(id: "`+uuid.Must(uuid.NewV4()).String()+`") { id } }`, nil)
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "NOT_FOUND" {
+		t.Errorf("errors = %+v, want NOT_FOUND", resp.Errors)
+	}
+}
+
+func TestCreateTask(t *testing.T) {
+	taskID := uuid.Must(uuid.NewV4())
+
+	service := &mocks.Service{}
+	service.On("CreateTask", mock.Anything, &entities.TaskRequest{
+		Title:  "Write docs",
+		Status: entities.StatusInProgress,
+		Labels: []string{"docs"},
+	}).Return(&entities.TaskResponse{ID: taskID, Title: "Write docs", Status: entities.StatusInProgress, Labels: []string{"docs"}}, nil)
+
+	const mutation = `mutation($input: TaskInput!) { createTask(input: $input) { id labels } }`
+
+	resp := do(t, service, mutation, map[string]interface{}{
+		"input": map[string]interface{}{"title": "Write docs", "status": "InProgress", "labels": []string{"docs"}},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors = %v", resp.Errors)
+	}
+	var got struct {
+		ID     string
+		Labels []string
+	}
+	json.Unmarshal(resp.Data["createTask"], &got)
+	if got.ID != taskID.String() || len(got.Labels) != 1 {
+		t.Errorf("createTask = %+v", got)
+	}
+
+	// Inputs go through the REST validation rules
+	resp = do(t, service, mutation, map[string]interface{}{
+		"input": map[string]interface{}{"title": "", "status": "Pending"},
+	})
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "BAD_REQUEST" {
+		t.Errorf("errors = %+v, want BAD_REQUEST", resp.Errors)
+	}
+	service.AssertNumberOfCalls(t, "CreateTask", 1)
+}
+
+func TestLimits(t *testing.T) {
+	deep := "{ tasks { id } }"
+	for i := 0; i < maxDepth; i++ {
+		deep = "{ __schema { types { fields { type " + deep[1:len(deep)-1] + " } } } }"
+	}
+	aliases := "{"
+	for i := 0; i <= maxFields; i++ {
+		aliases += fmt.Sprintf(" t%d: tasks { id }", i)
+	}
+	aliases += " }"
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{name: "introspection", query: testutil.IntrospectionQuery},
+		{name: "too deep", query: deep, wantErr: true},
+		{name: "too many fields", query: aliases, wantErr: true},
+		{name: "cyclic fragments", query: "{ ...A } fragment A on Query { ...A }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLimits(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	resp := do(t, &mocks.Service{}, aliases, nil)
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "BAD_REQUEST" || resp.Data != nil {
+		t.Errorf("response = %+v, want a BAD_REQUEST error and no data", resp)
+	}
+}
@@ -0,0 +1,87 @@
+package gql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+const (
+	// maxDepth bounds the nesting of selections. The schema itself is
+	// shallow, the limit leaves room for the introspection query of tools.
+	maxDepth = 15
+	// maxFields bounds the selected fields once fragments are expanded, so
+	// aliases cannot repeat costly fields in a single request
+	maxFields = 500
+)
+
+// checkLimits rejects queries nested deeper than maxDepth or selecting more
+// than maxFields fields. Syntax errors are left to graphql.Do to report.
+func checkLimits(query string) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	c := &limitChecker{fragments: fragments, visiting: map[string]bool{}}
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok {
+			if err := c.walk(op.SelectionSet, 1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type limitChecker struct {
+	fragments map[string]*ast.FragmentDefinition
+	// visiting holds the fragments being expanded, to stop at cycles
+	visiting map[string]bool
+	fields   int
+}
+
+func (c *limitChecker) walk(set *ast.SelectionSet, depth int) error {
+	if set == nil {
+		return nil
+	}
+	if depth > maxDepth {
+		return badRequest(fmt.Sprintf("query is nested deeper than %d levels", maxDepth))
+	}
+
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if c.fields++; c.fields > maxFields {
+				return badRequest(fmt.Sprintf("query selects more than %d fields", maxFields))
+			}
+			if err := c.walk(s.SelectionSet, depth+1); err != nil {
+				return err
+			}
+		case *ast.InlineFragment:
+			if err := c.walk(s.SelectionSet, depth); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			fragment, ok := c.fragments[s.Name.Value]
+			if !ok || c.visiting[s.Name.Value] {
+				continue
+			}
+			c.visiting[s.Name.Value] = true
+			err := c.walk(fragment.SelectionSet, depth)
+			c.visiting[s.Name.Value] = false
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"task-management/internal/services"
+
+	"github.com/gofrs/uuid"
+)
+
+// labelLoader batches the label lookups of a request. Resolvers queue task
+// IDs and return thunks; the executor runs the thunks once the whole level of
+// the response has been resolved, so the first one loads every queued task
+// with a single call.
+type labelLoader struct {
+	service services.Service
+	ctx     context.Context
+
+	mu      sync.Mutex
+	pending []uuid.UUID
+	labels  map[uuid.UUID][]string
+	err     error
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, service services.Service) context.Context {
+	return context.WithValue(ctx, loaderKey{}, &labelLoader{
+		service: service,
+		ctx:     ctx,
+		labels:  make(map[uuid.UUID][]string),
+	})
+}
+
+func loaderFrom(ctx context.Context) *labelLoader {
+	return ctx.Value(loaderKey{}).(*labelLoader)
+}
+
+// load queues the task and returns a thunk resolving to its labels
+func (l *labelLoader) load(taskID uuid.UUID) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.labels[taskID]; !ok {
+		l.pending = append(l.pending, taskID)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			labels, err := l.service.GetTaskLabels(l.ctx, l.pending)
+			l.pending = nil
+			if err != nil {
+				l.err = err
+			}
+			for id, names := range labels {
+				l.labels[id] = names
+			}
+		}
+
+		names, ok := l.labels[taskID]
+		if !ok && l.err != nil {
+			return nil, codedError(l.err)
+		}
+		if !ok {
+			names = []string{}
+		}
+		return names, nil
+	}
+}
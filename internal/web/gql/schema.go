@@ -0,0 +1,335 @@
+// Package gql serves the GraphQL API over tasks and their labels. Resolvers
+// call the service layer, and the labels of every task in a response are
+// loaded together.
+package gql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"task-management/internal/entities"
+	"task-management/internal/filter"
+	"task-management/internal/services"
+
+	"github.com/go-playground/validator"
+	"github.com/gofrs/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+type resolver struct {
+	service  services.Service
+	validate *validator.Validate
+}
+
+var taskStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "TaskStatus",
+	Values: graphql.EnumValueConfigMap{
+		string(entities.StatusPending):    {Value: entities.StatusPending},
+		string(entities.StatusInProgress): {Value: entities.StatusInProgress},
+		string(entities.StatusCompleted):  {Value: entities.StatusCompleted},
+		string(entities.StatusCancelled):  {Value: entities.StatusCancelled},
+	},
+})
+
+var taskInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "TaskInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":       {Type: graphql.NewNonNull(graphql.String)},
+		"description": {Type: graphql.String},
+		"status":      {Type: graphql.NewNonNull(taskStatusEnum)},
+		"dueDate":     {Type: graphql.DateTime},
+		"projectId":   {Type: graphql.ID},
+		"labels":      {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+	},
+})
+
+// taskField resolves a field of a task with get
+func taskField(get func(task *entities.TaskResponse) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*entities.TaskResponse)), nil
+	}
+}
+
+// NewSchema builds the GraphQL schema on top of the service layer. Task
+// inputs go through the same validation as the REST API.
+func NewSchema(service services.Service, validate *validator.Validate) (graphql.Schema, error) {
+	r := &resolver{service: service, validate: validate}
+
+	taskType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.Fields{
+			"id": {Type: graphql.NewNonNull(graphql.ID), Resolve: taskField(func(t *entities.TaskResponse) interface{} {
+				return t.ID.String()
+			})},
+			"title": {Type: graphql.NewNonNull(graphql.String), Resolve: taskField(func(t *entities.TaskResponse) interface{} {
+				return t.Title
+			})},
+			"description": {Type: graphql.NewNonNull(graphql.String), Resolve: taskField(func(t *entities.TaskResponse) interface{} {
+				return t.Description
+			})},
+			"status": {Type: graphql.NewNonNull(taskStatusEnum), Resolve: taskField(func(t *entities.TaskResponse) interface{} {
+				return t.Status
+			})},
+			"dueDate": {Type: graphql.DateTime, Resolve: taskField(func(t *entities.TaskResponse) interface{} {
+				return t.DueDate
+			})},
+			"projectId": {Type: graphql.ID, Resolve: taskField(func(t *entities.TaskResponse) interface{} {
+				if t.ProjectID == nil {
+					return nil
+				}
+				return t.ProjectID.String()
+			})},
+			"createdAt": {Type: graphql.NewNonNull(graphql.DateTime), Resolve: taskField(func(t *entities.TaskResponse) interface{} {
+				return t.CreatedAt
+			})},
+			"updatedAt": {Type: graphql.NewNonNull(graphql.DateTime), Resolve: taskField(func(t *entities.TaskResponse) interface{} {
+				return t.UpdatedAt
+			})},
+			"labels": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: r.taskLabels,
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"task": {
+				Type: taskType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.task,
+			},
+			"tasks": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taskType))),
+				Description: "Tasks with the pagination, filtering and ordering of GET /api/tasks",
+				Args: graphql.FieldConfigArgument{
+					"page":     {Type: graphql.Int, Description: "Page number, 1 by default"},
+					"pageSize": {Type: graphql.Int, Description: "Page size, 10 by default and at most 100"},
+					"status":   {Type: taskStatusEnum},
+					"sort":     {Type: graphql.String, Description: "Comma separated sort keys, prefix with - for descending"},
+					"filter":   {Type: graphql.String, Description: "Filter expression, e.g. status:InProgress AND label:backend"},
+					"view":     {Type: graphql.ID, Description: "Saved view providing defaults for the other arguments"},
+				},
+				Resolve: r.tasks,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTask": {
+				Type: graphql.NewNonNull(taskType),
+				Args: graphql.FieldConfigArgument{
+					"input": {Type: graphql.NewNonNull(taskInput)},
+				},
+				Resolve: r.createTask,
+			},
+			"updateTask": {
+				Type: graphql.NewNonNull(taskType),
+				Args: graphql.FieldConfigArgument{
+					"id":    {Type: graphql.NewNonNull(graphql.ID)},
+					"input": {Type: graphql.NewNonNull(taskInput)},
+				},
+				Resolve: r.updateTask,
+			},
+			"deleteTask": {
+				Type: graphql.NewNonNull(graphql.ID),
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.deleteTask,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func (r *resolver) task(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := r.service.GetTaskByID(p.Context, id, entities.TaskIncludes{})
+	if err != nil {
+		return nil, codedError(err)
+	}
+
+	return task, nil
+}
+
+func (r *resolver) tasks(p graphql.ResolveParams) (interface{}, error) {
+	opts, err := r.listOptions(p.Context, p.Args)
+	if err != nil {
+		return nil, codedError(err)
+	}
+
+	// Labels are left to the loader, which only runs when they are selected
+	tasks, err := r.service.GetAllTasks(p.Context, opts)
+	if err != nil {
+		return nil, codedError(err)
+	}
+
+	return tasks, nil
+}
+
+// listOptions reads the arguments of the tasks query. As with GET /api/tasks,
+// a saved view provides defaults for every argument not given explicitly.
+func (r *resolver) listOptions(ctx context.Context, args map[string]interface{}) (entities.TaskListOptions, error) {
+	opts := entities.TaskListOptions{Page: 1, PageSize: 10}
+	if page, ok := args["page"].(int); ok && page > 0 {
+		opts.Page = page
+	}
+	pageSize, hasPageSize := args["pageSize"].(int)
+	if hasPageSize && pageSize > 0 {
+		opts.PageSize = min(pageSize, entities.MaxPageSize)
+	}
+
+	if status, ok := args["status"].(entities.TaskStatus); ok {
+		opts.Status = &status
+	}
+	sortParam, _ := args["sort"].(string)
+	filterParam, _ := args["filter"].(string)
+
+	if raw, ok := args["view"].(string); ok {
+		viewID, err := uuid.FromString(raw)
+		if err != nil {
+			return opts, fmt.Errorf("%w: invalid view ID", entities.ErrInvalidView)
+		}
+
+		view, err := r.service.GetViewByID(ctx, viewID)
+		if err != nil {
+			return opts, err
+		}
+
+		if opts.Status == nil {
+			opts.Status = view.Status
+		}
+		if sortParam == "" {
+			sortParam = view.Sort
+		}
+		if filterParam == "" {
+			filterParam = view.Filter
+		}
+		if !hasPageSize && view.PageSize > 0 {
+			opts.PageSize = view.PageSize
+		}
+	}
+
+	var err error
+	if opts.Sort, err = entities.ParseTaskSort(sortParam); err != nil {
+		return opts, err
+	}
+	if opts.Filter, err = filter.Parse(filterParam); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+func (r *resolver) createTask(p graphql.ResolveParams) (interface{}, error) {
+	req, err := r.taskRequest(p.Args["input"])
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := r.service.CreateTask(p.Context, req)
+	if err != nil {
+		return nil, codedError(err)
+	}
+
+	return task, nil
+}
+
+func (r *resolver) updateTask(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := r.taskRequest(p.Args["input"])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.service.UpdateTask(p.Context, id, req); err != nil {
+		return nil, codedError(err)
+	}
+
+	task, err := r.service.GetTaskByID(p.Context, id, entities.TaskIncludes{Labels: true})
+	if err != nil {
+		return nil, codedError(err)
+	}
+
+	return task, nil
+}
+
+func (r *resolver) deleteTask(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.service.DeleteTask(p.Context, id); err != nil {
+		return nil, codedError(err)
+	}
+
+	return id.String(), nil
+}
+
+// taskLabels resolves the labels of a task. Tasks returned with their labels
+// use them; the others are batched by the request's loader.
+func (r *resolver) taskLabels(p graphql.ResolveParams) (interface{}, error) {
+	task := p.Source.(*entities.TaskResponse)
+	if task.Labels != nil {
+		return task.Labels, nil
+	}
+
+	return loaderFrom(p.Context).load(task.ID), nil
+}
+
+// taskRequest converts and validates a TaskInput
+func (r *resolver) taskRequest(raw interface{}) (*entities.TaskRequest, error) {
+	input := raw.(map[string]interface{})
+
+	req := &entities.TaskRequest{}
+	req.Title, _ = input["title"].(string)
+	req.Description, _ = input["description"].(string)
+	req.Status, _ = input["status"].(entities.TaskStatus)
+	if dueDate, ok := input["dueDate"].(time.Time); ok {
+		req.DueDate = &dueDate
+	}
+	if rawProject, ok := input["projectId"].(string); ok {
+		projectID, err := uuid.FromString(rawProject)
+		if err != nil {
+			return nil, badRequest("invalid projectId")
+		}
+		req.ProjectID = &projectID
+	}
+	if labels, ok := input["labels"].([]interface{}); ok {
+		for _, label := range labels {
+			req.Labels = append(req.Labels, label.(string))
+		}
+	}
+
+	if err := r.validate.Struct(req); err != nil {
+		return nil, badRequest(err.Error())
+	}
+
+	return req, nil
+}
+
+func parseID(raw interface{}) (uuid.UUID, error) {
+	s, _ := raw.(string)
+	id, err := uuid.FromString(s)
+	if err != nil {
+		return id, badRequest(fmt.Sprintf("invalid ID %q", s))
+	}
+	return id, nil
+}
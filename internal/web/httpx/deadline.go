@@ -0,0 +1,15 @@
+package httpx
+
+import (
+	"net/http"
+	"time"
+)
+
+// Unbound lifts the server's read and write timeouts for the response w is
+// writing, for event streams, WebSockets and downloads which outlive them.
+// Writers without deadlines, such as test recorders, are left as they are.
+func Unbound(w http.ResponseWriter) {
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+}
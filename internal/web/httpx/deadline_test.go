@@ -0,0 +1,49 @@
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnbound(t *testing.T) {
+	tests := []struct {
+		name    string
+		unbound bool
+		want    string
+	}{
+		{name: "bounded", unbound: false, want: ""},
+		{name: "unbound", unbound: true, want: "done"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Wrapped like the middlewares do, so the deadlines are
+				// reached through Unwrap
+				w = NewRecorder(w)
+				if tt.unbound {
+					Unbound(w)
+				}
+				time.Sleep(100 * time.Millisecond)
+				io.WriteString(w, "done")
+			}))
+			server.Config.ReadTimeout = 50 * time.Millisecond
+			server.Config.WriteTimeout = 50 * time.Millisecond
+			server.Start()
+			defer server.Close()
+
+			var got string
+			resp, err := http.Get(server.URL)
+			if err == nil {
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				got = string(body)
+			}
+			if got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Package httpx holds the pieces the HTTP middlewares of the server share.
+package httpx
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Recorder captures the status code and size of a response. It keeps the
+// optional interfaces event streams and WebSockets rely on.
+type Recorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+	hijacked    bool
+}
+
+// NewRecorder wraps w, the status defaulting to 200 until one is written
+func NewRecorder(w http.ResponseWriter) *Recorder {
+	return &Recorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+// Status returns the status code of the response, 101 once hijacked
+func (r *Recorder) Status() int {
+	return r.status
+}
+
+// Bytes returns the size of the response body written so far
+func (r *Recorder) Bytes() int64 {
+	return r.bytes
+}
+
+// Hijacked reports whether the connection was taken over, e.g. by a
+// WebSocket
+func (r *Recorder) Hijacked() bool {
+	return r.hijacked
+}
+
+func (r *Recorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *Recorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *Recorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *Recorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		r.hijacked = true
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *Recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,62 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	tests := []struct {
+		name  string
+		serve func(w http.ResponseWriter)
+		want  int
+	}{
+		{name: "implicit", serve: func(w http.ResponseWriter) { w.Write([]byte("ok")) }, want: http.StatusOK},
+		{name: "explicit", serve: func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) }, want: http.StatusNotFound},
+		{name: "first status wins", serve: func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusAccepted)
+			w.WriteHeader(http.StatusInternalServerError)
+		}, want: http.StatusAccepted},
+		{name: "status after body", serve: func(w http.ResponseWriter) {
+			w.Write([]byte("ok"))
+			w.WriteHeader(http.StatusInternalServerError)
+		}, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewRecorder(httptest.NewRecorder())
+			tt.serve(rec)
+			if rec.Status() != tt.want {
+				t.Errorf("Status() = %d, want %d", rec.Status(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRecorder_Bytes(t *testing.T) {
+	rec := NewRecorder(httptest.NewRecorder())
+	rec.Write([]byte("hello "))
+	rec.Write([]byte("world"))
+	if rec.Bytes() != 11 {
+		t.Errorf("Bytes() = %d, want 11", rec.Bytes())
+	}
+}
+
+func TestRecorder_Hijack(t *testing.T) {
+	rec := NewRecorder(httptest.NewRecorder())
+	if _, _, err := rec.Hijack(); err == nil {
+		t.Fatal("Hijack() error = nil, want an error from a writer without hijacking")
+	}
+	if rec.Hijacked() {
+		t.Error("Hijacked() = true after a failed hijack")
+	}
+
+	if _, ok := interface{}(rec).(http.Flusher); !ok {
+		t.Error("Recorder is not an http.Flusher")
+	}
+	if http.NewResponseController(rec).Flush() != nil {
+		t.Error("Flush() through the response controller failed")
+	}
+}
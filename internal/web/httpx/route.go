@@ -0,0 +1,22 @@
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Unmatched stands for the route of a request the router did not match
+const Unmatched = "unmatched"
+
+// Route returns the template of the route matching r, e.g.
+// /api/tasks/{id}, which keeps labels and log fields bounded. It returns
+// Unmatched outside of a routed request.
+func Route(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return Unmatched
+}
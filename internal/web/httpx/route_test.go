@@ -0,0 +1,26 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRoute(t *testing.T) {
+	var got string
+	router := mux.NewRouter()
+	router.HandleFunc("/api/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		got = Route(r)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/tasks/42", nil))
+	if got != "/api/tasks/{id}" {
+		t.Errorf("Route() = %q, want /api/tasks/{id}", got)
+	}
+
+	if got := Route(httptest.NewRequest("GET", "/api/tasks/42", nil)); got != Unmatched {
+		t.Errorf("Route() outside of the router = %q, want %q", got, Unmatched)
+	}
+}
@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"net/http"
+
+	"task-management/internal/auth"
+
+	"github.com/gofrs/uuid"
+)
+
+// identity stores the caller forwarded by the gateway in the request context.
+// Requests without a valid X-User-ID stay anonymous; endpoints that need a
+// caller reject them on their own.
+func identity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, err := uuid.FromString(r.Header.Get("X-User-ID"))
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		caller := auth.Caller{UserID: userID}
+		if projectID, err := uuid.FromString(r.Header.Get("X-Project-ID")); err == nil {
+			caller.ProjectID = &projectID
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithCaller(r.Context(), caller)))
+	})
+}
@@ -1,21 +1,73 @@
 package rest
 
 import (
+	"log/slog"
+
 	"task-management/internal/handlers"
+	"task-management/internal/health"
+	"task-management/internal/logging"
+	"task-management/internal/metrics"
+	"task-management/internal/ratelimit"
+	"task-management/internal/tracing"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// NewRouter returns a new router instance with configured routes
-func NewRouter(h *handlers.Handler) *mux.Router {
+// NewRouter returns a new router instance with configured routes. Requests
+// are logged to logger and throttled by limiter, unless it is nil.
+func NewRouter(h *handlers.Handler, checker *health.Checker, logger *slog.Logger, limiter *ratelimit.Limiter) *mux.Router {
 	router := mux.NewRouter()
+	router.Use(tracing.Middleware, logging.Middleware(logger), identity, metrics.Middleware, h.Timeout)
+	if limiter != nil {
+		router.Use(limiter.Middleware)
+	}
+
+	// Probe endpoints
+	router.HandleFunc("/healthz", checker.Healthz).Methods("GET")
+	router.HandleFunc("/readyz", checker.Readyz).Methods("GET")
+	router.HandleFunc("/status", checker.Status).Methods("GET")
+	router.Handle("/metrics", checker.Protect(promhttp.Handler())).Methods("GET")
 
 	// Task endpoints
 	router.HandleFunc("/api/tasks", h.V1.GetAllTasks).Methods("GET")
 	router.HandleFunc("/api/tasks", h.V1.CreateTask).Methods("POST")
+	router.HandleFunc("/api/tasks/search", h.V1.SearchTasks).Methods("GET")
+	router.HandleFunc("/api/tasks/export", h.V1.ExportTasks).Methods("GET")
+	router.HandleFunc("/api/tasks/bulk", h.V1.BulkTasks).Methods("POST")
+	router.HandleFunc("/api/tasks/import", h.V1.ImportTasks).Methods("POST")
 	router.HandleFunc("/api/tasks/{id}", h.V1.GetTaskByID).Methods("GET")
 	router.HandleFunc("/api/tasks/{id}", h.V1.UpdateTask).Methods("PUT")
 	router.HandleFunc("/api/tasks/{id}", h.V1.DeleteTask).Methods("DELETE")
 
+	// Saved view endpoints
+	router.HandleFunc("/api/views", h.V1.GetViews).Methods("GET")
+	router.HandleFunc("/api/views", h.V1.CreateView).Methods("POST")
+	router.HandleFunc("/api/views/{id}", h.V1.GetViewByID).Methods("GET")
+	router.HandleFunc("/api/views/{id}", h.V1.UpdateView).Methods("PUT")
+	router.HandleFunc("/api/views/{id}", h.V1.DeleteView).Methods("DELETE")
+
+	// Calendar endpoints
+	router.HandleFunc("/api/calendar.ics", h.V1.GetCalendarFeed).Methods("GET")
+	router.HandleFunc("/api/calendar/tokens", h.V1.GetCalendarTokens).Methods("GET")
+	router.HandleFunc("/api/calendar/tokens", h.V1.CreateCalendarToken).Methods("POST")
+	router.HandleFunc("/api/calendar/tokens/{id}", h.V1.DeleteCalendarToken).Methods("DELETE")
+
+	// Webhook endpoints
+	router.HandleFunc("/api/webhooks", h.V1.GetWebhooks).Methods("GET")
+	router.HandleFunc("/api/webhooks", h.V1.CreateWebhook).Methods("POST")
+	router.HandleFunc("/api/webhooks/{id}", h.V1.GetWebhookByID).Methods("GET")
+	router.HandleFunc("/api/webhooks/{id}", h.V1.UpdateWebhook).Methods("PUT")
+	router.HandleFunc("/api/webhooks/{id}", h.V1.DeleteWebhook).Methods("DELETE")
+	router.HandleFunc("/api/webhooks/{id}/deliveries", h.V1.GetWebhookDeliveries).Methods("GET")
+
+	// Event stream endpoints
+	router.HandleFunc("/api/events", h.V1.StreamEvents).Methods("GET")
+	router.HandleFunc("/api/ws/tickets", h.V1.CreateCollabTicket).Methods("POST")
+	router.HandleFunc("/api/ws", h.V1.Collaborate).Methods("GET")
+
+	// GraphQL endpoint
+	router.Handle("/graphql", h.GraphQL).Methods("POST")
+
 	return router
 }
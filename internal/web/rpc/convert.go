@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"fmt"
+	"time"
+
+	"task-management/internal/entities"
+	"task-management/internal/web/rpc/taskpb"
+
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var statuses = map[entities.TaskStatus]taskpb.TaskStatus{
+	entities.StatusPending:    taskpb.TaskStatus_TASK_STATUS_PENDING,
+	entities.StatusInProgress: taskpb.TaskStatus_TASK_STATUS_IN_PROGRESS,
+	entities.StatusCompleted:  taskpb.TaskStatus_TASK_STATUS_COMPLETED,
+	entities.StatusCancelled:  taskpb.TaskStatus_TASK_STATUS_CANCELLED,
+}
+
+// fromStatus converts a protobuf task status, refusing unknown values
+func fromStatus(s taskpb.TaskStatus) (entities.TaskStatus, error) {
+	for taskStatus, value := range statuses {
+		if value == s {
+			return taskStatus, nil
+		}
+	}
+	return "", status.Error(codes.InvalidArgument, fmt.Sprintf("invalid status %s", s))
+}
+
+// fromTaskInput converts a task input to a task request. An unspecified
+// status is left empty for validation to reject.
+func fromTaskInput(input *taskpb.TaskInput) (*entities.TaskRequest, error) {
+	req := &entities.TaskRequest{
+		Title:       input.GetTitle(),
+		Description: input.GetDescription(),
+		DueDate:     fromTimestamp(input.GetDueDate()),
+		Labels:      input.GetLabels(),
+	}
+
+	if input.GetStatus() != taskpb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		taskStatus, err := fromStatus(input.GetStatus())
+		if err != nil {
+			return nil, err
+		}
+		req.Status = taskStatus
+	}
+
+	if input.GetProjectId() != "" {
+		projectID, err := uuid.FromString(input.GetProjectId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid project_id")
+		}
+		req.ProjectID = &projectID
+	}
+
+	return req, nil
+}
+
+func toTask(task *entities.TaskResponse) *taskpb.Task {
+	return &taskpb.Task{
+		Id:          task.ID.String(),
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      statuses[task.Status],
+		DueDate:     toTimestamp(task.DueDate),
+		ProjectId:   uuidString(task.ProjectID),
+		CreatedAt:   timestamppb.New(task.CreatedAt),
+		UpdatedAt:   timestamppb.New(task.UpdatedAt),
+		Labels:      task.Labels,
+	}
+}
+
+func toEvent(id int64, event *entities.TaskEvent) *taskpb.TaskEvent {
+	response := &taskpb.TaskEvent{
+		Id:         id,
+		Type:       string(event.Type),
+		TaskId:     event.TaskID.String(),
+		ProjectId:  uuidString(event.ProjectID),
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+	if event.Task != nil {
+		response.Task = toTask(event.Task)
+	}
+	if event.PreviousStatus != nil {
+		response.PreviousStatus = statuses[*event.PreviousStatus]
+	}
+	return response
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"task-management/internal/entities"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError maps service errors to the gRPC status reported to the client,
+// following the HTTP statuses of the REST API
+func statusError(err error) error {
+	return status.Error(codeFor(err), err.Error())
+}
+
+func codeFor(err error) codes.Code {
+	switch entities.KindOf(err) {
+	case entities.KindInvalid:
+		return codes.InvalidArgument
+	case entities.KindUnauthenticated:
+		return codes.Unauthenticated
+	case entities.KindForbidden:
+		return codes.PermissionDenied
+	case entities.KindNotFound:
+		return codes.NotFound
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"context"
+
+	"task-management/internal/auth"
+
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// withCaller stores the caller forwarded by the gateway in the x-user-id and
+// x-project-id metadata in the context. Calls without a valid x-user-id stay
+// anonymous, like requests to the REST API.
+func withCaller(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	userID, err := uuid.FromString(first(md.Get("x-user-id")))
+	if err != nil {
+		return ctx
+	}
+	caller := auth.Caller{UserID: userID}
+	if projectID, err := uuid.FromString(first(md.Get("x-project-id"))); err == nil {
+		caller.ProjectID = &projectID
+	}
+
+	return auth.WithCaller(ctx, caller)
+}
+
+func unaryIdentity(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withCaller(ctx), req)
+}
+
+func streamIdentity(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &callerStream{ServerStream: ss, ctx: withCaller(ss.Context())})
+}
+
+// callerStream overrides the context of a server stream
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
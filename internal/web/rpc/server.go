@@ -0,0 +1,266 @@
+// Package rpc serves the gRPC API. It exposes the same task operations as
+// the REST API on top of the service layer.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	"task-management/internal/filter"
+	"task-management/internal/logging"
+	"task-management/internal/services"
+	"task-management/internal/stream"
+	"task-management/internal/web/rpc/taskpb"
+
+	"github.com/go-playground/validator"
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type server struct {
+	taskpb.UnimplementedTaskServiceServer
+
+	service  services.Service
+	validate *validator.Validate
+	events   *stream.Broker
+}
+
+// NewServer returns a gRPC server with the task service registered. Calls
+// are logged to logger.
+func NewServer(service services.Service, validate *validator.Validate, events *stream.Broker, logger *slog.Logger) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(logger), unaryIdentity),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(logger), streamIdentity),
+	)
+	taskpb.RegisterTaskServiceServer(s, &server{service: service, validate: validate, events: events})
+	return s
+}
+
+// CreateTask adds a new task
+func (s *server) CreateTask(ctx context.Context, req *taskpb.CreateTaskRequest) (*taskpb.Task, error) {
+	input, err := s.taskRequest(req.GetTask())
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.service.CreateTask(ctx, input)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toTask(task), nil
+}
+
+// GetTask retrieves a task by its ID
+func (s *server) GetTask(ctx context.Context, req *taskpb.GetTaskRequest) (*taskpb.Task, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.service.GetTaskByID(ctx, id, entities.TaskIncludes{Labels: req.GetIncludeLabels()})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toTask(task), nil
+}
+
+// ListTasks retrieves tasks with pagination, optional status filtering, a
+// filter expression and ordering
+func (s *server) ListTasks(ctx context.Context, req *taskpb.ListTasksRequest) (*taskpb.ListTasksResponse, error) {
+	opts := entities.TaskListOptions{
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+		Include:  entities.TaskIncludes{Labels: req.GetIncludeLabels()},
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PageSize < 1 {
+		opts.PageSize = 10
+	}
+	if opts.PageSize > entities.MaxPageSize {
+		opts.PageSize = entities.MaxPageSize
+	}
+	if req.GetStatus() != taskpb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		taskStatus, err := fromStatus(req.GetStatus())
+		if err != nil {
+			return nil, err
+		}
+		opts.Status = &taskStatus
+	}
+
+	var err error
+	if opts.Sort, err = entities.ParseTaskSort(req.GetSort()); err != nil {
+		return nil, statusError(err)
+	}
+	if opts.Filter, err = filter.Parse(req.GetFilter()); err != nil {
+		return nil, statusError(err)
+	}
+
+	tasks, err := s.service.GetAllTasks(ctx, opts)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	response := &taskpb.ListTasksResponse{Tasks: make([]*taskpb.Task, len(tasks))}
+	for i, task := range tasks {
+		response.Tasks[i] = toTask(task)
+	}
+
+	return response, nil
+}
+
+// UpdateTask updates an existing task and returns it
+func (s *server) UpdateTask(ctx context.Context, req *taskpb.UpdateTaskRequest) (*taskpb.Task, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := s.taskRequest(req.GetTask())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.UpdateTask(ctx, id, input); err != nil {
+		return nil, statusError(err)
+	}
+
+	task, err := s.service.GetTaskByID(ctx, id, entities.TaskIncludes{Labels: true})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toTask(task), nil
+}
+
+// DeleteTask removes a task by its ID
+func (s *server) DeleteTask(ctx context.Context, req *taskpb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteTask(ctx, id); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// WatchTasks streams the task events visible to the caller, like the
+// Server-Sent Events stream of the REST API. Response headers are sent once
+// the watch is in place. The stream ends with
+// Unavailable when the client falls too far behind; the client should then
+// call again with the ID of the last event it received.
+func (s *server) WatchTasks(req *taskpb.WatchTasksRequest, ss grpc.ServerStreamingServer[taskpb.TaskEvent]) error {
+	f, err := watchFilter(ss.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	sub, backlog, resumed := s.events.Subscribe(req.LastEventId, f.Match)
+	defer sub.Close()
+
+	// The headers tell the client the watch is in place
+	if err := ss.SendHeader(nil); err != nil {
+		return err
+	}
+
+	if !resumed {
+		if err := ss.Send(&taskpb.TaskEvent{Type: "reset"}); err != nil {
+			return err
+		}
+	}
+	for _, event := range backlog {
+		if err := sendEvent(ss, event); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ss.Context().Done():
+			return nil
+		case event, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.Unavailable, "event stream interrupted, watch again from the last event")
+			}
+			if err := sendEvent(ss, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchFilter builds the event filter of a watch, refusing projects the
+// caller cannot see
+func watchFilter(ctx context.Context, req *taskpb.WatchTasksRequest) (stream.Filter, error) {
+	caller, _ := auth.FromContext(ctx)
+	f := stream.Filter{Caller: caller}
+
+	if req.GetProjectId() != "" {
+		projectID, err := uuid.FromString(req.GetProjectId())
+		if err != nil {
+			return f, status.Error(codes.InvalidArgument, "invalid project_id")
+		}
+		if !caller.CanSeeProject(&projectID) {
+			return f, statusError(entities.ErrForbidden)
+		}
+		f.ProjectID = &projectID
+	}
+
+	for _, s := range req.GetStatuses() {
+		taskStatus, err := fromStatus(s)
+		if err != nil {
+			return f, err
+		}
+		f.Statuses = append(f.Statuses, taskStatus)
+	}
+
+	return f, nil
+}
+
+func sendEvent(ss grpc.ServerStreamingServer[taskpb.TaskEvent], event stream.Event) error {
+	var task entities.TaskEvent
+	if err := json.Unmarshal(event.Data, &task); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return ss.Send(toEvent(event.ID, &task))
+}
+
+// taskRequest converts and validates a task input with the validation rules
+// of the REST API
+func (s *server) taskRequest(input *taskpb.TaskInput) (*entities.TaskRequest, error) {
+	if input == nil {
+		return nil, status.Error(codes.InvalidArgument, "task is required")
+	}
+
+	req, err := fromTaskInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validate.Struct(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return req, nil
+}
+
+func parseID(raw string) (uuid.UUID, error) {
+	id, err := uuid.FromString(raw)
+	if err != nil {
+		return id, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid task ID %q", raw))
+	}
+	return id, nil
+}
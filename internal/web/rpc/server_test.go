@@ -0,0 +1,209 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"task-management/internal/entities"
+	"task-management/internal/services/mocks"
+	"task-management/internal/stream"
+	"task-management/internal/web/rpc/taskpb"
+
+	"github.com/go-playground/validator"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T, service *mocks.Service, events *stream.Broker) taskpb.TaskServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(service, validator.New(), events, slog.New(slog.DiscardHandler))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return taskpb.NewTaskServiceClient(conn)
+}
+
+func TestServer_CreateTask(t *testing.T) {
+	taskID := uuid.Must(uuid.NewV4())
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	service := &mocks.Service{}
+	service.On("CreateTask", mock.Anything, &entities.TaskRequest{
+		Title:   "Write docs",
+		Status:  entities.StatusInProgress,
+		DueDate: &due,
+		Labels:  []string{"docs"},
+	}).Return(&entities.TaskResponse{ID: taskID, Title: "Write docs", Status: entities.StatusInProgress, DueDate: &due, Labels: []string{"docs"}}, nil)
+
+	client := newTestClient(t, service, nil)
+
+	tests := []struct {
+		name     string
+		input    *taskpb.TaskInput
+		wantCode codes.Code
+	}{
+		{
+			name: "created",
+			input: &taskpb.TaskInput{
+				Title:   "Write docs",
+				Status:  taskpb.TaskStatus_TASK_STATUS_IN_PROGRESS,
+				DueDate: toTimestamp(&due),
+				Labels:  []string{"docs"},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "missing title fails validation",
+			input:    &taskpb.TaskInput{Status: taskpb.TaskStatus_TASK_STATUS_PENDING},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing status fails validation",
+			input:    &taskpb.TaskInput{Title: "Write docs"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid project",
+			input:    &taskpb.TaskInput{Title: "Write docs", Status: taskpb.TaskStatus_TASK_STATUS_PENDING, ProjectId: "nope"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.CreateTask(context.Background(), &taskpb.CreateTaskRequest{Task: tt.input})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CreateTask() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if err == nil && (got.GetId() != taskID.String() || got.GetStatus() != taskpb.TaskStatus_TASK_STATUS_IN_PROGRESS) {
+				t.Errorf("CreateTask() = %v", got)
+			}
+		})
+	}
+}
+
+func TestServer_GetTask_notFound(t *testing.T) {
+	service := &mocks.Service{}
+	service.On("GetTaskByID", mock.Anything, mock.Anything, entities.TaskIncludes{}).Return(nil, entities.ErrTaskNotFound)
+
+	client := newTestClient(t, service, nil)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-42")
+	var header metadata.MD
+	_, err := client.GetTask(ctx, &taskpb.GetTaskRequest{Id: uuid.Must(uuid.NewV4()).String()}, grpc.Header(&header))
+	if code := status.Code(err); code != codes.NotFound {
+		t.Errorf("GetTask() code = %v, want NotFound", code)
+	}
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "req-42" {
+		t.Errorf("x-request-id header = %v, want req-42", got)
+	}
+
+	_, err = client.GetTask(context.Background(), &taskpb.GetTaskRequest{Id: "nope"})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("GetTask() code = %v, want InvalidArgument", code)
+	}
+}
+
+func TestServer_ListTasks_pageSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageSize int32
+		want     int
+	}{
+		{name: "default", want: 10},
+		{name: "requested", pageSize: 25, want: 25},
+		{name: "clamped", pageSize: 100000, want: entities.MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mocks.Service{}
+			service.On("GetAllTasks", mock.Anything, mock.MatchedBy(func(opts entities.TaskListOptions) bool {
+				return opts.PageSize == tt.want
+			})).Return([]*entities.TaskResponse{}, nil)
+
+			client := newTestClient(t, service, nil)
+
+			if _, err := client.ListTasks(context.Background(), &taskpb.ListTasksRequest{PageSize: tt.pageSize}); err != nil {
+				t.Fatalf("ListTasks() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestServer_WatchTasks(t *testing.T) {
+	projectID := uuid.Must(uuid.NewV4())
+	otherProject := uuid.Must(uuid.NewV4())
+	taskID := uuid.Must(uuid.NewV4())
+
+	events := stream.NewBroker(stream.DefaultLogSize)
+	client := newTestClient(t, &mocks.Service{}, events)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-user-id", uuid.Must(uuid.NewV4()).String(), "x-project-id", projectID.String())
+
+	forbidden, err := client.WatchTasks(ctx, &taskpb.WatchTasksRequest{ProjectId: otherProject.String()})
+	if err == nil {
+		_, err = forbidden.Recv()
+	}
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Fatalf("WatchTasks() other project code = %v, want PermissionDenied", code)
+	}
+
+	// Resuming from an event no longer in the log starts with a reset
+	stale := int64(42)
+	watch, err := client.WatchTasks(ctx, &taskpb.WatchTasksRequest{LastEventId: &stale})
+	if err != nil {
+		t.Fatalf("WatchTasks() error = %v", err)
+	}
+	if _, err := watch.Header(); err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+	if reset, err := watch.Recv(); err != nil || reset.GetType() != "reset" {
+		t.Fatalf("WatchTasks() first event = %v, %v, want reset", reset, err)
+	}
+
+	publish := func(id int64, project uuid.UUID) {
+		payload, _ := json.Marshal(entities.TaskEvent{
+			Type:      entities.EventTaskDeleted,
+			TaskID:    taskID,
+			ProjectID: &project,
+		})
+		event, err := stream.NewEvent(id, payload)
+		if err != nil {
+			t.Fatalf("NewEvent() error = %v", err)
+		}
+		events.Publish(event)
+	}
+
+	publish(1, otherProject)
+	publish(2, projectID)
+
+	got, err := watch.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if got.GetId() != 2 || got.GetTaskId() != taskID.String() || got.GetType() != string(entities.EventTaskDeleted) {
+		t.Errorf("Recv() = %v, want the deletion in the caller's project", got)
+	}
+}
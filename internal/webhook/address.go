@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"task-management/internal/entities"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, as unreachable from the
+// outside as the private ranges
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// CheckURL rejects webhook URLs that are not plain http(s) or that point at
+// the server's own network: loopback, private, link-local and similar
+// addresses. Host names are checked again once resolved, when delivering.
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entities.ErrInvalidWebhookURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme must be http or https", entities.ErrInvalidWebhookURL)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s is a local address", entities.ErrInvalidWebhookURL, host)
+	}
+	if ip := net.ParseIP(host); ip != nil && !isPublic(ip) {
+		return fmt.Errorf("%w: %s is not a public address", entities.ErrInvalidWebhookURL, host)
+	}
+	return nil
+}
+
+// isPublic reports whether ip may be reached from the internet
+func isPublic(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// dialPublic refuses connections to addresses that are not public, so a
+// subscriber's host name resolving to an internal address does not reach
+// the server's network. It runs after resolution, for every address tried.
+func dialPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublic(ip) {
+		return fmt.Errorf("%w: %s is not a public address", entities.ErrInvalidWebhookURL, host)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"context"
+
+	"task-management/internal/auth"
+	"task-management/internal/entities"
+	webhookModel "task-management/internal/models/webhook"
+)
+
+// Publisher turns outbox events into deliveries for every active
+// subscription that asked for them and may see the task, for the worker to
+// send
+type Publisher struct {
+	model webhookModel.Webhook
+}
+
+// NewPublisher creates the outbox consumer feeding webhook deliveries
+func NewPublisher(model webhookModel.Webhook) *Publisher {
+	return &Publisher{model: model}
+}
+
+// Name implements outbox.Publisher
+func (p *Publisher) Name() string {
+	return "webhooks"
+}
+
+// Publish implements outbox.Publisher. Events published twice are only
+// queued once per subscription.
+func (p *Publisher) Publish(ctx context.Context, events []entities.OutboxEvent) error {
+	subscriptions, err := p.model.GetActive(ctx)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	var deliveries []entities.WebhookDelivery
+	for _, event := range events {
+		for _, subscription := range subscriptions {
+			owner := auth.Caller{UserID: subscription.UserID, ProjectID: subscription.ProjectID}
+			if !subscription.Wants(event.Type) || !owner.CanSeeProject(event.ProjectID) {
+				continue
+			}
+			deliveries = append(deliveries, entities.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				EventID:        event.EventID,
+				EventType:      event.Type,
+				Payload:        event.Payload,
+				Status:         entities.DeliveryPending,
+				NextAttemptAt:  event.CreatedAt,
+			})
+		}
+	}
+
+	return p.model.CreateDeliveries(ctx, deliveries)
+}
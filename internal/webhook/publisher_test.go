@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"task-management/internal/entities"
+	webhookMock "task-management/internal/models/webhook/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	allID, _ := uuid.NewV4()
+	statusID, _ := uuid.NewV4()
+	updatedID, _ := uuid.NewV4()
+	changedID, _ := uuid.NewV4()
+
+	var queued []entities.WebhookDelivery
+	model := webhookMock.Webhook{}
+	model.On("GetActive", mock.Anything).Return([]entities.WebhookSubscription{
+		{ID: allID, Active: true},
+		{ID: statusID, Active: true, Events: "task.status_changed"},
+	}, nil)
+	model.On("CreateDeliveries", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]entities.WebhookDelivery)
+	}).Return(nil)
+
+	err := NewPublisher(&model).Publish(context.Background(), []entities.OutboxEvent{
+		{ID: 1, EventID: updatedID, Type: entities.EventTaskUpdated, Payload: "{}"},
+		{ID: 2, EventID: changedID, Type: entities.EventTaskStatusChanged, Payload: "{}"},
+	})
+	if err != nil {
+		t.Fatalf("Publisher.Publish() error = %v", err)
+	}
+
+	// The catch-all subscription gets both events, the other one only the status change
+	want := []struct {
+		subscription uuid.UUID
+		event        uuid.UUID
+	}{
+		{allID, updatedID},
+		{allID, changedID},
+		{statusID, changedID},
+	}
+	if len(queued) != len(want) {
+		t.Fatalf("Publisher.Publish() queued %d deliveries, want %d", len(queued), len(want))
+	}
+	for i, w := range want {
+		if queued[i].SubscriptionID != w.subscription || queued[i].EventID != w.event {
+			t.Errorf("delivery %d = %s for %s, want %s for %s", i, queued[i].EventID, queued[i].SubscriptionID, w.event, w.subscription)
+		}
+		if queued[i].Status != entities.DeliveryPending {
+			t.Errorf("delivery %d status = %q", i, queued[i].Status)
+		}
+	}
+}
+
+func TestPublisher_Publish_projects(t *testing.T) {
+	projectID, _ := uuid.NewV4()
+	otherProjectID, _ := uuid.NewV4()
+	memberID, _ := uuid.NewV4()
+	outsiderID, _ := uuid.NewV4()
+	projectEventID, _ := uuid.NewV4()
+	sharedEventID, _ := uuid.NewV4()
+
+	var queued []entities.WebhookDelivery
+	model := webhookMock.Webhook{}
+	model.On("GetActive", mock.Anything).Return([]entities.WebhookSubscription{
+		{ID: memberID, Active: true, ProjectID: &projectID},
+		{ID: outsiderID, Active: true, ProjectID: &otherProjectID},
+	}, nil)
+	model.On("CreateDeliveries", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]entities.WebhookDelivery)
+	}).Return(nil)
+
+	err := NewPublisher(&model).Publish(context.Background(), []entities.OutboxEvent{
+		{ID: 1, EventID: projectEventID, Type: entities.EventTaskCreated, ProjectID: &projectID, Payload: "{}"},
+		{ID: 2, EventID: sharedEventID, Type: entities.EventTaskCreated, Payload: "{}"},
+	})
+	if err != nil {
+		t.Fatalf("Publisher.Publish() error = %v", err)
+	}
+
+	// Tasks without a project are visible to everyone, the others only to
+	// their project
+	want := []struct {
+		subscription uuid.UUID
+		event        uuid.UUID
+	}{
+		{memberID, projectEventID},
+		{memberID, sharedEventID},
+		{outsiderID, sharedEventID},
+	}
+	if len(queued) != len(want) {
+		t.Fatalf("Publisher.Publish() queued %d deliveries, want %d", len(queued), len(want))
+	}
+	for i, w := range want {
+		if queued[i].SubscriptionID != w.subscription || queued[i].EventID != w.event {
+			t.Errorf("delivery %d = %s for %s, want %s for %s", i, queued[i].EventID, queued[i].SubscriptionID, w.event, w.subscription)
+		}
+	}
+}
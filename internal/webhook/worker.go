@@ -0,0 +1,212 @@
+// Package webhook delivers queued task events to webhook subscribers.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"task-management/internal/entities"
+	webhookModel "task-management/internal/models/webhook"
+)
+
+const (
+	// MaxAttempts is the number of times a delivery is tried before it is
+	// given up on
+	MaxAttempts = 8
+
+	// baseBackoff is the wait before the first retry, doubled for every
+	// further one up to maxBackoff
+	baseBackoff = 30 * time.Second
+	maxBackoff  = time.Hour
+
+	pollInterval   = 5 * time.Second
+	batchSize      = 20
+	requestTimeout = 10 * time.Second
+
+	// lease keeps claimed deliveries away from other workers while they are
+	// being sent. It must outlast a request.
+	lease = 2 * requestTimeout
+
+	// maxErrorLength bounds the error text kept in the delivery log
+	maxErrorLength = 500
+)
+
+// Headers sent along with every delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Worker sends pending deliveries and schedules retries for failed ones.
+// Several workers can run side by side, each delivery is only claimed by one.
+type Worker struct {
+	model  webhookModel.Webhook
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewWorker creates a new delivery worker
+func NewWorker(model webhookModel.Webhook) *Worker {
+	return &Worker{
+		model: model,
+		client: &http.Client{
+			Timeout: requestTimeout,
+			Transport: &http.Transport{
+				DialContext:         (&net.Dialer{Timeout: requestTimeout, Control: dialPublic}).DialContext,
+				TLSHandshakeTimeout: requestTimeout,
+				MaxIdleConnsPerHost: batchSize,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			// Subscribers must answer themselves, redirects count as failures
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Run delivers due events until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while there is a backlog instead of waiting for the next tick
+		for {
+			n, err := w.RunOnce(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "webhook worker: delivery batch failed", "error", err)
+			}
+			if err != nil || n < batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends one batch of due deliveries and returns how many were sent
+func (w *Worker) RunOnce(ctx context.Context) (int, error) {
+	deliveries, err := w.model.ClaimDue(ctx, w.now(), batchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		wg.Add(1)
+		go func(d *entities.WebhookDelivery) {
+			defer wg.Done()
+			w.deliver(ctx, d)
+			if err := w.model.UpdateDelivery(ctx, d); err != nil {
+				slog.ErrorContext(ctx, "webhook worker: failed to record delivery", "delivery_id", d.ID, "error", err)
+			}
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver makes one attempt at sending d and records the outcome on it
+func (w *Worker) deliver(ctx context.Context, d *entities.WebhookDelivery) {
+	d.Attempts++
+
+	status, err := w.send(ctx, d)
+	d.ResponseStatus = status
+	if err == nil {
+		now := w.now()
+		d.Status = entities.DeliverySucceeded
+		d.Error = ""
+		d.DeliveredAt = &now
+		return
+	}
+
+	d.Error = err.Error()
+	if len(d.Error) > maxErrorLength {
+		d.Error = d.Error[:maxErrorLength]
+	}
+
+	if d.Attempts >= MaxAttempts {
+		d.Status = entities.DeliveryFailed
+		return
+	}
+	d.NextAttemptAt = w.now().Add(Backoff(d.Attempts))
+}
+
+// send posts the payload of d to its subscriber
+func (w *Worker) send(ctx context.Context, d *entities.WebhookDelivery) (int, error) {
+	subscription := d.Subscription
+	if subscription == nil || !subscription.Active {
+		return 0, fmt.Errorf("subscription is disabled")
+	}
+
+	body := []byte(d.Payload)
+	timestamp := w.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-management-webhooks")
+	req.Header.Set(HeaderEvent, string(d.EventType))
+	req.Header.Set(HeaderDelivery, d.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("subscriber answered %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for a payload: the hex encoded
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret.
+// Signing the timestamp lets subscribers reject replayed deliveries.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the wait before retrying a delivery that failed attempts
+// times
+func Backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+	return wait
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"task-management/internal/entities"
+	webhookMock "task-management/internal/models/webhook/mocks"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{20, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWorker_RunOnce(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	payload := `{"type":"task.created"}`
+
+	var gotSignature, gotTimestamp, gotBody string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotSignature = r.Header.Get(HeaderSignature)
+		gotTimestamp = r.Header.Get(HeaderTimestamp)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	subscription := &entities.WebhookSubscription{URL: server.URL, Secret: "secret", Active: true}
+
+	tests := []struct {
+		name        string
+		status      int
+		attempts    int
+		wantStatus  entities.WebhookDeliveryStatus
+		wantRetryAt time.Time
+	}{
+		{name: "delivered", status: http.StatusNoContent, wantStatus: entities.DeliverySucceeded},
+		{name: "retried", status: http.StatusInternalServerError, attempts: 2, wantStatus: entities.DeliveryPending, wantRetryAt: now.Add(2 * time.Minute)},
+		{name: "given up", status: http.StatusInternalServerError, attempts: MaxAttempts - 1, wantStatus: entities.DeliveryFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status = tt.status
+			id, _ := uuid.NewV4()
+			delivery := entities.WebhookDelivery{
+				ID:            id,
+				EventType:     entities.EventTaskCreated,
+				Payload:       payload,
+				Status:        entities.DeliveryPending,
+				Attempts:      tt.attempts,
+				NextAttemptAt: now,
+				Subscription:  subscription,
+			}
+
+			var recorded *entities.WebhookDelivery
+			model := webhookMock.Webhook{}
+			model.On("ClaimDue", mock.Anything, now, batchSize, lease).Return([]entities.WebhookDelivery{delivery}, nil)
+			model.On("UpdateDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				recorded = args.Get(1).(*entities.WebhookDelivery)
+			}).Return(nil)
+
+			w := NewWorker(&model)
+			w.now = func() time.Time { return now }
+			// The test server listens on loopback, which the worker refuses
+			w.client.Transport = server.Client().Transport
+
+			n, err := w.RunOnce(context.Background())
+			if err != nil || n != 1 {
+				t.Fatalf("Worker.RunOnce() = %d, %v", n, err)
+			}
+
+			if gotBody != payload {
+				t.Errorf("body = %q, want %q", gotBody, payload)
+			}
+			if gotTimestamp != strconv.FormatInt(now.Unix(), 10) {
+				t.Errorf("timestamp = %q", gotTimestamp)
+			}
+			if want := Sign("secret", now.Unix(), []byte(payload)); gotSignature != want {
+				t.Errorf("signature = %q, want %q", gotSignature, want)
+			}
+
+			if recorded.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", recorded.Status, tt.wantStatus)
+			}
+			if recorded.Attempts != tt.attempts+1 {
+				t.Errorf("attempts = %d, want %d", recorded.Attempts, tt.attempts+1)
+			}
+			if recorded.ResponseStatus != tt.status {
+				t.Errorf("response status = %d, want %d", recorded.ResponseStatus, tt.status)
+			}
+			if !tt.wantRetryAt.IsZero() && !recorded.NextAttemptAt.Equal(tt.wantRetryAt) {
+				t.Errorf("next attempt = %v, want %v", recorded.NextAttemptAt, tt.wantRetryAt)
+			}
+		})
+	}
+}
+
+func TestWorker_RunOnce_privateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("delivery reached a loopback address")
+	}))
+	defer server.Close()
+
+	// URLs are checked when saved, but host names may resolve to another
+	// address later on, so the worker checks every address it dials
+	delivery := entities.WebhookDelivery{
+		EventType:    entities.EventTaskCreated,
+		Payload:      "{}",
+		Status:       entities.DeliveryPending,
+		Subscription: &entities.WebhookSubscription{URL: server.URL, Secret: "secret", Active: true},
+	}
+
+	var recorded *entities.WebhookDelivery
+	model := webhookMock.Webhook{}
+	model.On("ClaimDue", mock.Anything, mock.Anything, batchSize, lease).Return([]entities.WebhookDelivery{delivery}, nil)
+	model.On("UpdateDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*entities.WebhookDelivery)
+	}).Return(nil)
+
+	if _, err := NewWorker(&model).RunOnce(context.Background()); err != nil {
+		t.Fatalf("Worker.RunOnce() error = %v", err)
+	}
+	if recorded.Status != entities.DeliveryPending || !strings.Contains(recorded.Error, "not a public address") {
+		t.Errorf("delivery to loopback = %q with error %q, want it refused", recorded.Status, recorded.Error)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://hooks.example.com/tasks", false},
+		{"http://203.0.113.10:8080/hook", false},
+		{"ftp://hooks.example.com/tasks", true},
+		{"http://localhost:8080/hook", true},
+		{"http://api.localhost/hook", true},
+		{"http://127.0.0.1/hook", true},
+		{"http://[::1]/hook", true},
+		{"http://10.1.2.3/hook", true},
+		{"http://192.168.0.10/hook", true},
+		{"http://169.254.169.254/latest/meta-data", true},
+		{"http://[fe80::1]/hook", true},
+		{"http://100.64.0.1/hook", true},
+		{"http://0.0.0.0/hook", true},
+	}
+
+	for _, tt := range tests {
+		err := CheckURL(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, entities.ErrInvalidWebhookURL) {
+			t.Errorf("CheckURL(%q) error = %v, want %v", tt.url, err, entities.ErrInvalidWebhookURL)
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	// printf '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got := Sign("secret", 1700000000, []byte("{}")); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}